@@ -0,0 +1,167 @@
+package go_tronsdk
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync/atomic"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	APIKeyHeader = "TRON-PRO-API-KEY"
+)
+
+// HeaderProvider supplies the authentication headers of each request. It is called for every
+// HTTP request and gRPC call, so implementations could rotate keys or refresh tokens.
+type HeaderProvider interface {
+	Headers(ctx context.Context) (http.Header, error)
+}
+
+type HeaderProviderFunc func(ctx context.Context) (http.Header, error)
+
+func (f HeaderProviderFunc) Headers(ctx context.Context) (http.Header, error) {
+	return f(ctx)
+}
+
+type StaticHeaders http.Header
+
+func (h StaticHeaders) Headers(_ context.Context) (http.Header, error) {
+	return http.Header(h), nil
+}
+
+// APIKey provides TRON-PRO-API-KEY header for TronGrid
+func APIKey(key string) HeaderProvider {
+	h := make(http.Header)
+	h.Set(APIKeyHeader, key)
+	return StaticHeaders(h)
+}
+
+// RotatingAPIKeys uses the keys in turn, one for each request.
+func RotatingAPIKeys(keys ...string) HeaderProvider {
+	if len(keys) == 0 {
+		return StaticHeaders(nil)
+	}
+	var next atomic.Uint64
+	return HeaderProviderFunc(func(_ context.Context) (http.Header, error) {
+		i := next.Add(1) - 1
+		h := make(http.Header)
+		h.Set(APIKeyHeader, keys[i%uint64(len(keys))])
+		return h, nil
+	})
+}
+
+// ContextWithHeaders returns a context carrying headers, which will be set to the HTTP requests
+// made by HttpClient with it, after the headers from HeaderProvider.
+func ContextWithHeaders(ctx context.Context, h http.Header) context.Context {
+	if src := headersFromContext(ctx); src != nil {
+		h = setHeaders(src.Clone(), h)
+	}
+	return context.WithValue(ctx, mdHeaderKey{}, h)
+}
+
+func headersToMetadata(ctx context.Context, p HeaderProvider) (context.Context, error) {
+	h, err := p.Headers(ctx)
+	if err != nil {
+		return ctx, fmt.Errorf("auth headers: %w", err)
+	}
+	if len(h) == 0 {
+		return ctx, nil
+	}
+	kv := make([]string, 0, 2*len(h))
+	for k, vs := range h {
+		for _, v := range vs {
+			kv = append(kv, strings.ToLower(k), v)
+		}
+	}
+	return metadata.AppendToOutgoingContext(ctx, kv...), nil
+}
+
+func headerUnaryInterceptor(p HeaderProvider) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		ctx, err := headersToMetadata(ctx, p)
+		if err != nil {
+			return err
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+func headerStreamInterceptor(p HeaderProvider) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string,
+		streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		ctx, err := headersToMetadata(ctx, p)
+		if err != nil {
+			return nil, err
+		}
+		return streamer(ctx, desc, cc, method, opts...)
+	}
+}
+
+// headerTransport sets the headers of HeaderProvider to each HTTP request, with the context of
+// the request
+type headerTransport struct {
+	base     http.RoundTripper
+	provider HeaderProvider
+}
+
+func (t *headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	h, err := t.provider.Headers(req.Context())
+	if err != nil {
+		return nil, fmt.Errorf("auth headers: %w", err)
+	}
+	if len(h) > 0 {
+		req = req.Clone(req.Context())
+		setHeaders(req.Header, h)
+	}
+	base := t.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return base.RoundTrip(req)
+}
+
+// LoadTLSConfig creates a TLS client config. Empty caFile means using the system roots,
+// certFile and keyFile are optional and only needed by mutual TLS.
+func LoadTLSConfig(caFile, certFile, keyFile string) (*tls.Config, error) {
+	cfg := &tls.Config{MinVersion: tls.VersionTLS12}
+	if caFile != "" {
+		pem, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, err
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificate found in %s", caFile)
+		}
+		cfg.RootCAs = pool
+	}
+	if certFile != "" || keyFile != "" {
+		if certFile == "" || keyFile == "" {
+			return nil, errors.New("both cert and key files are required")
+		}
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, err
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+	return cfg, nil
+}
+
+func newHttpTransportClient(tlsConfig *tls.Config) *http.Client {
+	if tlsConfig == nil {
+		return new(http.Client)
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig.Clone()
+	return &http.Client{Transport: transport}
+}
@@ -0,0 +1,337 @@
+package go_tronsdk
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"google.golang.org/grpc"
+)
+
+// ReadBackend is the queries served by both fullnodes and solidity nodes. The answers of a
+// solidity node only include solidified (confirmed) data.
+type ReadBackend interface {
+	GetNowBlock(ctx context.Context) (*api.BlockExtention, error)
+	// GetBlock gets block by id (hex) or number (decimal), transactions are included if detail is true
+	GetBlock(ctx context.Context, idOrNum string, detail bool) (*api.BlockExtention, error)
+	GetAccount(ctx context.Context, addr address.Address) (*core.Account, error)
+	GetTransactionById(ctx context.Context, txId []byte) (*core.Transaction, error)
+	GetTransactionInfoById(ctx context.Context, txId []byte) (*core.TransactionInfo, error)
+	TriggerConstantContract(ctx context.Context, tsc *core.TriggerSmartContract) (*api.TransactionExtention, error)
+	// EstimateEnergy estimates the energy of the call, which is disabled by default on fullnodes
+	EstimateEnergy(ctx context.Context, tsc *core.TriggerSmartContract) (*api.EstimateEnergyMessage, error)
+	ListWitnesses(ctx context.Context) (*api.WitnessList, error)
+	// GetAssetIssueById gets the TRC10 token by its id, such as "1002000"
+	GetAssetIssueById(ctx context.Context, id string) (*core.AssetIssueContract, error)
+	// GetDelegatedResourceV2 gets the Stake 2.0 resources delegated from one account to another
+	GetDelegatedResourceV2(ctx context.Context, from, to address.Address) (*api.DelegatedResourceList, error)
+	// GetDelegatedResourceAccountIndexV2 gets the accounts delegating to or delegated by addr
+	GetDelegatedResourceAccountIndexV2(ctx context.Context, addr address.Address) (*core.DelegatedResourceAccountIndex, error)
+	GetCanDelegatedMaxSize(ctx context.Context, owner address.Address, resource core.ResourceCode) (*api.CanDelegatedMaxSizeResponseMessage, error)
+	// GetRewardInfo gets the unclaimed voting rewards of addr in sun
+	GetRewardInfo(ctx context.Context, addr address.Address) (int64, error)
+	// GetBrokerageInfo gets the brokerage ratio (percent) of the witness
+	GetBrokerageInfo(ctx context.Context, witness address.Address) (int64, error)
+}
+
+// Backend is the wallet API of a TRON fullnode, independent of the protocol it is served by.
+// GrpcBackend and HttpClient implement it over gRPC and the /wallet/* HTTP API. Timeouts are
+// applied by the callers, such as TronClient.
+type Backend interface {
+	ReadBackend
+	// GetBlockByLimitNext gets blocks of [start, end)
+	GetBlockByLimitNext(ctx context.Context, start, end int64) (*api.BlockListExtention, error)
+	GetAccountResource(ctx context.Context, addr address.Address) (*api.AccountResourceMessage, error)
+	TriggerContract(ctx context.Context, tsc *core.TriggerSmartContract) (*api.TransactionExtention, error)
+	BroadcastTransaction(ctx context.Context, tx *core.Transaction) (*api.Return, error)
+	GetChainParameters(ctx context.Context) (*core.ChainParameters, error)
+	GetContract(ctx context.Context, addr address.Address) (*core.SmartContract, error)
+	GetNextMaintenanceTime(ctx context.Context) (time.Time, error)
+	// GetTransactionSignWeight gets the weight of the signatures of tx in its permission
+	GetTransactionSignWeight(ctx context.Context, tx *core.Transaction) (*api.TransactionSignWeight, error)
+	// GetTransactionApprovedList gets the addresses recovered from the signatures of tx
+	GetTransactionApprovedList(ctx context.Context, tx *core.Transaction) (*api.TransactionApprovedList, error)
+}
+
+// GrpcBackend implements Backend with api.WalletClient
+type GrpcBackend struct {
+	conn   *grpc.ClientConn
+	wallet api.WalletClient
+}
+
+func NewGrpcBackend(wallet api.WalletClient) *GrpcBackend {
+	return &GrpcBackend{wallet: wallet}
+}
+
+// DialGrpcBackend connects to the gRPC endpoint of a fullnode, such as grpc.trongrid.io:50051
+func DialGrpcBackend(ctx context.Context, url string, opts ...grpc.DialOption) (*GrpcBackend, error) {
+	conn, err := grpc.DialContext(ctx, url, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &GrpcBackend{conn: conn, wallet: api.NewWalletClient(conn)}, nil
+}
+
+func (b *GrpcBackend) Close() error {
+	if b.conn != nil {
+		return b.conn.Close()
+	}
+	return nil
+}
+
+func (b *GrpcBackend) Wallet() api.WalletClient {
+	return b.wallet
+}
+
+func (b *GrpcBackend) GetNowBlock(ctx context.Context) (*api.BlockExtention, error) {
+	return b.wallet.GetNowBlock2(ctx, &api.EmptyMessage{})
+}
+
+func (b *GrpcBackend) GetBlock(ctx context.Context, idOrNum string, detail bool) (*api.BlockExtention, error) {
+	return b.wallet.GetBlock(ctx, &api.BlockReq{IdOrNum: idOrNum, Detail: detail})
+}
+
+func (b *GrpcBackend) GetBlockByLimitNext(ctx context.Context, start, end int64) (*api.BlockListExtention, error) {
+	return b.wallet.GetBlockByLimitNext2(ctx, &api.BlockLimit{StartNum: start, EndNum: end})
+}
+
+func (b *GrpcBackend) GetAccount(ctx context.Context, addr address.Address) (*core.Account, error) {
+	return b.wallet.GetAccount(ctx, &core.Account{Address: addr})
+}
+
+func (b *GrpcBackend) GetAccountResource(ctx context.Context, addr address.Address) (*api.AccountResourceMessage, error) {
+	return b.wallet.GetAccountResource(ctx, &core.Account{Address: addr})
+}
+
+func (b *GrpcBackend) GetTransactionById(ctx context.Context, txId []byte) (*core.Transaction, error) {
+	return b.wallet.GetTransactionById(ctx, &api.BytesMessage{Value: txId})
+}
+
+func (b *GrpcBackend) GetTransactionInfoById(ctx context.Context, txId []byte) (*core.TransactionInfo, error) {
+	return b.wallet.GetTransactionInfoById(ctx, &api.BytesMessage{Value: txId})
+}
+
+func (b *GrpcBackend) TriggerConstantContract(ctx context.Context, tsc *core.TriggerSmartContract) (*api.TransactionExtention, error) {
+	return b.wallet.TriggerConstantContract(ctx, tsc)
+}
+
+func (b *GrpcBackend) EstimateEnergy(ctx context.Context, tsc *core.TriggerSmartContract) (*api.EstimateEnergyMessage, error) {
+	return b.wallet.EstimateEnergy(ctx, tsc)
+}
+
+func (b *GrpcBackend) TriggerContract(ctx context.Context, tsc *core.TriggerSmartContract) (*api.TransactionExtention, error) {
+	return b.wallet.TriggerContract(ctx, tsc)
+}
+
+func (b *GrpcBackend) BroadcastTransaction(ctx context.Context, tx *core.Transaction) (*api.Return, error) {
+	return b.wallet.BroadcastTransaction(ctx, tx)
+}
+
+func (b *GrpcBackend) GetChainParameters(ctx context.Context) (*core.ChainParameters, error) {
+	return b.wallet.GetChainParameters(ctx, &api.EmptyMessage{})
+}
+
+func (b *GrpcBackend) GetContract(ctx context.Context, addr address.Address) (*core.SmartContract, error) {
+	return b.wallet.GetContract(ctx, &api.BytesMessage{Value: addr})
+}
+
+func (b *GrpcBackend) ListWitnesses(ctx context.Context) (*api.WitnessList, error) {
+	return b.wallet.ListWitnesses(ctx, &api.EmptyMessage{})
+}
+
+func (b *GrpcBackend) GetAssetIssueById(ctx context.Context, id string) (*core.AssetIssueContract, error) {
+	return b.wallet.GetAssetIssueById(ctx, &api.BytesMessage{Value: []byte(id)})
+}
+
+func (b *GrpcBackend) GetDelegatedResourceV2(ctx context.Context, from, to address.Address) (*api.DelegatedResourceList, error) {
+	return b.wallet.GetDelegatedResourceV2(ctx, &api.DelegatedResourceMessage{FromAddress: from, ToAddress: to})
+}
+
+func (b *GrpcBackend) GetDelegatedResourceAccountIndexV2(ctx context.Context, addr address.Address) (*core.DelegatedResourceAccountIndex, error) {
+	return b.wallet.GetDelegatedResourceAccountIndexV2(ctx, &api.BytesMessage{Value: addr})
+}
+
+func (b *GrpcBackend) GetCanDelegatedMaxSize(ctx context.Context, owner address.Address, resource core.ResourceCode) (*api.CanDelegatedMaxSizeResponseMessage, error) {
+	return b.wallet.GetCanDelegatedMaxSize(ctx, &api.CanDelegatedMaxSizeRequestMessage{Type: int32(resource), OwnerAddress: owner})
+}
+
+func (b *GrpcBackend) GetRewardInfo(ctx context.Context, addr address.Address) (int64, error) {
+	num, err := b.wallet.GetRewardInfo(ctx, &api.BytesMessage{Value: addr})
+	if err != nil {
+		return 0, err
+	}
+	return num.GetNum(), nil
+}
+
+func (b *GrpcBackend) GetBrokerageInfo(ctx context.Context, witness address.Address) (int64, error) {
+	num, err := b.wallet.GetBrokerageInfo(ctx, &api.BytesMessage{Value: witness})
+	if err != nil {
+		return 0, err
+	}
+	return num.GetNum(), nil
+}
+
+func (b *GrpcBackend) GetNextMaintenanceTime(ctx context.Context) (time.Time, error) {
+	nm, err := b.wallet.GetNextMaintenanceTime(ctx, &api.EmptyMessage{})
+	if err != nil {
+		return time.Time{}, err
+	}
+	if nm == nil {
+		return time.Time{}, errors.New("empty maintenance time")
+	}
+	return maintenanceTime(nm.Num), nil
+}
+
+func (b *GrpcBackend) GetTransactionSignWeight(ctx context.Context, tx *core.Transaction) (*api.TransactionSignWeight, error) {
+	return b.wallet.GetTransactionSignWeight(ctx, tx)
+}
+
+func (b *GrpcBackend) GetTransactionApprovedList(ctx context.Context, tx *core.Transaction) (*api.TransactionApprovedList, error) {
+	return b.wallet.GetTransactionApprovedList(ctx, tx)
+}
+
+// maintenanceTime accepts both seconds and milliseconds
+func maintenanceTime(num int64) time.Time {
+	if num > 9999999999 {
+		return time.UnixMilli(num)
+	} else {
+		return time.Unix(num, 0)
+	}
+}
+
+// GrpcSolidityBackend implements ReadBackend with api.WalletSolidityClient
+type GrpcSolidityBackend struct {
+	conn     *grpc.ClientConn
+	solidity api.WalletSolidityClient
+}
+
+func NewGrpcSolidityBackend(solidity api.WalletSolidityClient) *GrpcSolidityBackend {
+	return &GrpcSolidityBackend{solidity: solidity}
+}
+
+// DialGrpcSolidityBackend connects to the gRPC endpoint of a solidity node, such as grpc.trongrid.io:50052
+func DialGrpcSolidityBackend(ctx context.Context, url string, opts ...grpc.DialOption) (*GrpcSolidityBackend, error) {
+	conn, err := grpc.DialContext(ctx, url, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &GrpcSolidityBackend{conn: conn, solidity: api.NewWalletSolidityClient(conn)}, nil
+}
+
+func (b *GrpcSolidityBackend) Close() error {
+	if b.conn != nil {
+		return b.conn.Close()
+	}
+	return nil
+}
+
+func (b *GrpcSolidityBackend) GetNowBlock(ctx context.Context) (*api.BlockExtention, error) {
+	return b.solidity.GetNowBlock2(ctx, &api.EmptyMessage{})
+}
+
+func (b *GrpcSolidityBackend) GetBlock(ctx context.Context, idOrNum string, detail bool) (*api.BlockExtention, error) {
+	return b.solidity.GetBlock(ctx, &api.BlockReq{IdOrNum: idOrNum, Detail: detail})
+}
+
+func (b *GrpcSolidityBackend) GetAccount(ctx context.Context, addr address.Address) (*core.Account, error) {
+	return b.solidity.GetAccount(ctx, &core.Account{Address: addr})
+}
+
+func (b *GrpcSolidityBackend) GetTransactionById(ctx context.Context, txId []byte) (*core.Transaction, error) {
+	return b.solidity.GetTransactionById(ctx, &api.BytesMessage{Value: txId})
+}
+
+func (b *GrpcSolidityBackend) GetTransactionInfoById(ctx context.Context, txId []byte) (*core.TransactionInfo, error) {
+	return b.solidity.GetTransactionInfoById(ctx, &api.BytesMessage{Value: txId})
+}
+
+func (b *GrpcSolidityBackend) TriggerConstantContract(ctx context.Context, tsc *core.TriggerSmartContract) (*api.TransactionExtention, error) {
+	return b.solidity.TriggerConstantContract(ctx, tsc)
+}
+
+func (b *GrpcSolidityBackend) EstimateEnergy(ctx context.Context, tsc *core.TriggerSmartContract) (*api.EstimateEnergyMessage, error) {
+	return b.solidity.EstimateEnergy(ctx, tsc)
+}
+
+func (b *GrpcSolidityBackend) ListWitnesses(ctx context.Context) (*api.WitnessList, error) {
+	return b.solidity.ListWitnesses(ctx, &api.EmptyMessage{})
+}
+
+func (b *GrpcSolidityBackend) GetAssetIssueById(ctx context.Context, id string) (*core.AssetIssueContract, error) {
+	return b.solidity.GetAssetIssueById(ctx, &api.BytesMessage{Value: []byte(id)})
+}
+
+func (b *GrpcSolidityBackend) GetDelegatedResourceV2(ctx context.Context, from, to address.Address) (*api.DelegatedResourceList, error) {
+	return b.solidity.GetDelegatedResourceV2(ctx, &api.DelegatedResourceMessage{FromAddress: from, ToAddress: to})
+}
+
+func (b *GrpcSolidityBackend) GetDelegatedResourceAccountIndexV2(ctx context.Context, addr address.Address) (*core.DelegatedResourceAccountIndex, error) {
+	return b.solidity.GetDelegatedResourceAccountIndexV2(ctx, &api.BytesMessage{Value: addr})
+}
+
+func (b *GrpcSolidityBackend) GetCanDelegatedMaxSize(ctx context.Context, owner address.Address, resource core.ResourceCode) (*api.CanDelegatedMaxSizeResponseMessage, error) {
+	return b.solidity.GetCanDelegatedMaxSize(ctx, &api.CanDelegatedMaxSizeRequestMessage{Type: int32(resource), OwnerAddress: owner})
+}
+
+func (b *GrpcSolidityBackend) GetRewardInfo(ctx context.Context, addr address.Address) (int64, error) {
+	num, err := b.solidity.GetRewardInfo(ctx, &api.BytesMessage{Value: addr})
+	if err != nil {
+		return 0, err
+	}
+	return num.GetNum(), nil
+}
+
+func (b *GrpcSolidityBackend) GetBrokerageInfo(ctx context.Context, witness address.Address) (int64, error) {
+	num, err := b.solidity.GetBrokerageInfo(ctx, &api.BytesMessage{Value: witness})
+	if err != nil {
+		return 0, err
+	}
+	return num.GetNum(), nil
+}
+
+var ErrNotSupportedBySolidity = errors.New("not supported by solidity node")
+
+// readOnlyBackend makes a ReadBackend a Backend, so that solidity nodes could be pooled
+// as fullnodes. The methods not in ReadBackend are never called by TronClient.
+type readOnlyBackend struct {
+	ReadBackend
+}
+
+func (b readOnlyBackend) GetBlockByLimitNext(context.Context, int64, int64) (*api.BlockListExtention, error) {
+	return nil, ErrNotSupportedBySolidity
+}
+
+func (b readOnlyBackend) GetAccountResource(context.Context, address.Address) (*api.AccountResourceMessage, error) {
+	return nil, ErrNotSupportedBySolidity
+}
+
+func (b readOnlyBackend) TriggerContract(context.Context, *core.TriggerSmartContract) (*api.TransactionExtention, error) {
+	return nil, ErrNotSupportedBySolidity
+}
+
+func (b readOnlyBackend) BroadcastTransaction(context.Context, *core.Transaction) (*api.Return, error) {
+	return nil, ErrNotSupportedBySolidity
+}
+
+func (b readOnlyBackend) GetChainParameters(context.Context) (*core.ChainParameters, error) {
+	return nil, ErrNotSupportedBySolidity
+}
+
+func (b readOnlyBackend) GetContract(context.Context, address.Address) (*core.SmartContract, error) {
+	return nil, ErrNotSupportedBySolidity
+}
+
+func (b readOnlyBackend) GetNextMaintenanceTime(context.Context) (time.Time, error) {
+	return time.Time{}, ErrNotSupportedBySolidity
+}
+
+func (b readOnlyBackend) GetTransactionSignWeight(context.Context, *core.Transaction) (*api.TransactionSignWeight, error) {
+	return nil, ErrNotSupportedBySolidity
+}
+
+func (b readOnlyBackend) GetTransactionApprovedList(context.Context, *core.Transaction) (*api.TransactionApprovedList, error) {
+	return nil, ErrNotSupportedBySolidity
+}
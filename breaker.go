@@ -0,0 +1,210 @@
+package go_tronsdk
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+type BreakerState int
+
+const (
+	// BreakerClosed lets all calls through
+	BreakerClosed BreakerState = iota
+	// BreakerOpen fails calls fast until OpenTimeout elapsed
+	BreakerOpen
+	// BreakerHalfOpen lets a few trial calls through to decide whether to close or reopen
+	BreakerHalfOpen
+)
+
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerClosed:
+		return "Closed"
+	case BreakerOpen:
+		return "Open"
+	case BreakerHalfOpen:
+		return "HalfOpen"
+	default:
+		return fmt.Sprintf("BreakerState(%d)", int(s))
+	}
+}
+
+// BreakerConfig configures the circuit breakers of endpoints. Zero values use the defaults.
+type BreakerConfig struct {
+	// FailureThreshold is the number of consecutive transport failures to open the breaker
+	FailureThreshold int
+	// OpenTimeout is how long the breaker stays open before trial calls are allowed
+	OpenTimeout time.Duration
+	// HalfOpenMaxCalls is the max number of concurrent trial calls in half-open state
+	HalfOpenMaxCalls int
+	// SuccessThreshold is the number of successful trial calls to close the breaker
+	SuccessThreshold int
+	// OnStateChange is called synchronously after the breaker of endpoint changed its state
+	OnStateChange func(endpoint string, from, to BreakerState)
+}
+
+const (
+	DefaultBreakerFailureThreshold = 5
+	DefaultBreakerOpenTimeout      = 30 * time.Second
+	DefaultBreakerHalfOpenMaxCalls = 1
+	DefaultBreakerSuccessThreshold = 1
+)
+
+func (cfg BreakerConfig) withDefaults() BreakerConfig {
+	if cfg.FailureThreshold <= 0 {
+		cfg.FailureThreshold = DefaultBreakerFailureThreshold
+	}
+	if cfg.OpenTimeout <= 0 {
+		cfg.OpenTimeout = DefaultBreakerOpenTimeout
+	}
+	if cfg.HalfOpenMaxCalls <= 0 {
+		cfg.HalfOpenMaxCalls = DefaultBreakerHalfOpenMaxCalls
+	}
+	if cfg.SuccessThreshold <= 0 {
+		cfg.SuccessThreshold = DefaultBreakerSuccessThreshold
+	}
+	return cfg
+}
+
+var ErrBreakerOpen = errors.New("circuit breaker open")
+
+// BreakerOpenError is returned without calling the endpoint when its breaker is open
+type BreakerOpenError struct {
+	Endpoint string
+	Until    time.Time // zero if the trial calls of half-open state are in progress
+}
+
+func (e *BreakerOpenError) Error() string {
+	if e.Until.IsZero() {
+		return fmt.Sprintf("circuit breaker of %s half-open", e.Endpoint)
+	}
+	return fmt.Sprintf("circuit breaker of %s open until %s", e.Endpoint, e.Until.Format(time.RFC3339))
+}
+
+func (e *BreakerOpenError) Is(target error) bool {
+	return target == ErrBreakerOpen
+}
+
+// CircuitBreaker of an endpoint, only transport failures are counted. A nil breaker lets all
+// calls through.
+type CircuitBreaker struct {
+	endpoint string
+	cfg      BreakerConfig
+
+	lock      sync.Mutex
+	state     BreakerState
+	failures  int
+	successes int
+	trials    int
+	openedAt  time.Time
+}
+
+func NewCircuitBreaker(endpoint string, cfg BreakerConfig) *CircuitBreaker {
+	return &CircuitBreaker{endpoint: endpoint, cfg: cfg.withDefaults()}
+}
+
+// setState must be called with lock held, returns the callback to be called after unlock
+func (b *CircuitBreaker) setState(to BreakerState) func() {
+	from := b.state
+	if from == to {
+		return nil
+	}
+	b.state = to
+	b.failures, b.successes, b.trials = 0, 0, 0
+	if to == BreakerOpen {
+		b.openedAt = time.Now()
+	}
+	if b.cfg.OnStateChange == nil {
+		return nil
+	}
+	return func() {
+		b.cfg.OnStateChange(b.endpoint, from, to)
+	}
+}
+
+func notify(cb func()) {
+	if cb != nil {
+		cb()
+	}
+}
+
+// Allow returns *BreakerOpenError if the call should not be sent. Each allowed call must be
+// followed by Record.
+func (b *CircuitBreaker) Allow() error {
+	if b == nil {
+		return nil
+	}
+	b.lock.Lock()
+	var cb func()
+	defer func() {
+		b.lock.Unlock()
+		notify(cb)
+	}()
+	if b.state == BreakerOpen {
+		until := b.openedAt.Add(b.cfg.OpenTimeout)
+		if time.Now().Before(until) {
+			return &BreakerOpenError{Endpoint: b.endpoint, Until: until}
+		}
+		cb = b.setState(BreakerHalfOpen)
+	}
+	if b.state == BreakerHalfOpen {
+		if b.trials >= b.cfg.HalfOpenMaxCalls {
+			return &BreakerOpenError{Endpoint: b.endpoint}
+		}
+		b.trials++
+	}
+	return nil
+}
+
+// Record the result of an allowed call
+func (b *CircuitBreaker) Record(failed bool) {
+	if b == nil {
+		return
+	}
+	b.lock.Lock()
+	var cb func()
+	defer func() {
+		b.lock.Unlock()
+		notify(cb)
+	}()
+	switch b.state {
+	case BreakerClosed:
+		if !failed {
+			b.failures = 0
+		} else if b.failures++; b.failures >= b.cfg.FailureThreshold {
+			cb = b.setState(BreakerOpen)
+		}
+	case BreakerHalfOpen:
+		if b.trials > 0 {
+			b.trials--
+		}
+		if failed {
+			cb = b.setState(BreakerOpen)
+		} else if b.successes++; b.successes >= b.cfg.SuccessThreshold {
+			cb = b.setState(BreakerClosed)
+		}
+	}
+}
+
+// cancel an allowed call which was not sent
+func (b *CircuitBreaker) cancel() {
+	if b == nil {
+		return
+	}
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if b.state == BreakerHalfOpen && b.trials > 0 {
+		b.trials--
+	}
+}
+
+func (b *CircuitBreaker) State() BreakerState {
+	if b == nil {
+		return BreakerClosed
+	}
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return b.state
+}
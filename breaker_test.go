@@ -0,0 +1,101 @@
+package go_tronsdk
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	var changes []BreakerState
+	b := NewCircuitBreaker("x", BreakerConfig{
+		FailureThreshold: 2,
+		OpenTimeout:      20 * time.Millisecond,
+		OnStateChange: func(endpoint string, from, to BreakerState) {
+			changes = append(changes, to)
+		},
+	})
+	for i := 0; i < 2; i++ {
+		if err := b.Allow(); err != nil {
+			t.Fatal(err)
+		}
+		b.Record(true)
+	}
+	if b.State() != BreakerOpen {
+		t.Fatalf("expecting open, got %s", b.State())
+	}
+	var boe *BreakerOpenError
+	if err := b.Allow(); !errors.As(err, &boe) || !errors.Is(err, ErrBreakerOpen) || boe.Endpoint != "x" {
+		t.Fatalf("expecting BreakerOpenError, got %v", err)
+	}
+
+	time.Sleep(25 * time.Millisecond)
+	if err := b.Allow(); err != nil {
+		t.Fatalf("trial call should be allowed, got %v", err)
+	}
+	if b.State() != BreakerHalfOpen || b.Allow() == nil {
+		t.Fatal("only one trial call expected in half-open state")
+	}
+	b.Record(true)
+	if b.State() != BreakerOpen {
+		t.Fatalf("failed trial should reopen, got %s", b.State())
+	}
+
+	time.Sleep(25 * time.Millisecond)
+	if err := b.Allow(); err != nil {
+		t.Fatal(err)
+	}
+	b.Record(false)
+	if b.State() != BreakerClosed {
+		t.Fatalf("successful trial should close, got %s", b.State())
+	}
+	want := []BreakerState{BreakerOpen, BreakerHalfOpen, BreakerOpen, BreakerHalfOpen, BreakerClosed}
+	if len(changes) != len(want) {
+		t.Fatalf("expecting changes %v, got %v", want, changes)
+	}
+	for i := range want {
+		if changes[i] != want[i] {
+			t.Fatalf("expecting changes %v, got %v", want, changes)
+		}
+	}
+}
+
+func TestNodePool_Breaker(t *testing.T) {
+	down := &fakeWallet{name: "down", err: status.Error(codes.Unavailable, "down")}
+	p := newFakePool(BalanceFailover, down)
+	defer p.Close()
+	p.setBreakers(&BreakerConfig{FailureThreshold: 2, OpenTimeout: time.Minute})
+	for i := 0; i < 4; i++ {
+		_, err := _poolRun(context.Background(), p, "GetAccount", time.Second, true, func(ctx context.Context, b Backend) (*core.Account, error) {
+			return b.GetAccount(ctx, nil)
+		})
+		if i >= 2 && !errors.Is(err, ErrBreakerOpen) {
+			t.Fatalf("expecting fail fast, got %v", err)
+		}
+	}
+	if down.calls != 2 {
+		t.Fatalf("open breaker should stop calls, got %d", down.calls)
+	}
+
+	up := &fakeWallet{name: "up"}
+	p2 := newFakePool(BalanceFailover, down, up)
+	defer p2.Close()
+	p2.setBreakers(&BreakerConfig{FailureThreshold: 1, OpenTimeout: time.Minute})
+	down.calls = 0
+	for i := 0; i < 3; i++ {
+		acc, err := _poolRun(context.Background(), p2, "GetAccount", time.Second, true, func(ctx context.Context, b Backend) (*core.Account, error) {
+			return b.GetAccount(ctx, nil)
+		})
+		if err != nil || string(acc.AccountName) != "up" {
+			t.Fatalf("expecting answer from up, got %v %v", acc, err)
+		}
+	}
+	if down.calls != 1 {
+		t.Fatalf("endpoint with open breaker should be skipped, got %d calls", down.calls)
+	}
+}
@@ -6,18 +6,22 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum"
 	ethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
-	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/fbsobreira/gotron-sdk/pkg/address"
 	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
 	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
 )
 
 const (
@@ -28,14 +32,20 @@ const (
 type TronClient struct {
 	httpUrl       string
 	http          *HttpClient
-	grpcUrl       string
-	fullnodeConn  *grpc.ClientConn
-	fullnodeGrpc  api.WalletClient
+	fullnodes     *nodePool
+	solidity      *nodePool
 	ethUrl        string
 	eth           *ethclient.Client
+	quorum        int
+	retry         *RetryPolicy
+	limiter       *RateLimiter
+	ethBreaker    *CircuitBreaker
+	assets        sync.Map // TRC10 asset id -> *TRC10Asset
 	chainid       *big.Int
 	timeout       time.Duration
 	GetTxInterval time.Duration
+
+	feeLimitMargin float64
 }
 
 func _timeoutRun[T any](ctx context.Context, d time.Duration, f func(context.Context) (T, error)) (t T, err error) {
@@ -49,58 +59,220 @@ func _timeoutRun[T any](ctx context.Context, d time.Duration, f func(context.Con
 	}
 }
 
+// _backendRun runs idempotent query f as method on the fullnode backends
+func _backendRun[T any](ctx context.Context, c *TronClient, method string, f func(context.Context, Backend) (T, error)) (t T, err error) {
+	if c.fullnodes == nil {
+		return t, TransportUnavailableError{Transport: TransportGRPC}
+	}
+	return _retryRun(ctx, c.retry, method, func(rctx context.Context) (T, error) {
+		return _poolRun(rctx, c.fullnodes, method, c.timeout, true, f)
+	})
+}
+
+func _ethRun[T any](ctx context.Context, c *TronClient, method string, f func(context.Context, *ethclient.Client) (T, error)) (t T, err error) {
+	if c.eth == nil {
+		return t, TransportUnavailableError{Transport: TransportJSONRPC}
+	}
+	return _retryRun(ctx, c.retry, method, func(rctx context.Context) (T, error) {
+		if err := c.ethBreaker.Allow(); err != nil {
+			return t, err
+		}
+		if err := c.limiter.Wait(rctx, c.ethUrl, method); err != nil {
+			c.ethBreaker.cancel()
+			return t, err
+		}
+		r, err := _timeoutRun(rctx, c.timeout, func(cctx context.Context) (T, error) {
+			return f(cctx, c.eth)
+		})
+		c.ethBreaker.Record(isTransportError(err))
+		return r, err
+	})
+}
+
 func NewTronClient(cctx context.Context, httpurl, grpcurl, ethurl string,
 	timeoutSeconds, getTxIntervalSeconds int64) (tc *TronClient, errr error) {
+	return NewClient(cctx,
+		WithHTTP(httpurl),
+		WithGRPC(grpcurl),
+		WithJSONRPC(ethurl),
+		WithTimeout(time.Duration(timeoutSeconds)*time.Second),
+		WithGetTxInterval(time.Duration(getTxIntervalSeconds)*time.Second),
+	)
+}
+
+// NewClient creates a TronClient with the transports enabled by opts, at least one of
+// WithHTTP, WithGRPC and WithJSONRPC is required. Methods depending on a transport which
+// is not enabled return TransportUnavailableError.
+func NewClient(cctx context.Context, opts ...Option) (tc *TronClient, errr error) {
+	o := defaultClientOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+	if o.httpUrl == "" && len(o.grpcUrls) == 0 && o.ethUrl == "" && len(o.backends) == 0 {
+		return nil, errors.New("no transport configured")
+	}
 	c := &TronClient{
-		httpUrl: httpurl,
-		grpcUrl: grpcurl,
-		ethUrl:  ethurl,
+		httpUrl:       o.httpUrl,
+		ethUrl:        o.ethUrl,
+		timeout:       o.timeout,
+		GetTxInterval: o.getTxInterval,
+		quorum:        o.quorum,
+		retry:         o.retry,
+		limiter:       o.limiter,
+
+		feeLimitMargin: o.feeLimitMargin,
 	}
 	defer func() {
 		if errr != nil {
 			_ = c.Close()
 		}
 	}()
-	to := int64(DefaultTimeoutSeconds)
-	if timeoutSeconds > 0 {
-		to = timeoutSeconds
+
+	if o.ethUrl != "" {
+		c.eth, errr = _timeoutRun(cctx, c.timeout, func(ctx context.Context) (*ethclient.Client, error) {
+			hc := newHttpTransportClient(o.tlsConfig)
+			if o.headers != nil {
+				// called with the context of each request
+				hc.Transport = &headerTransport{base: hc.Transport, provider: o.headers}
+			}
+			rc, err := rpc.DialOptions(ctx, o.ethUrl, rpc.WithHTTPClient(hc))
+			if err != nil {
+				return nil, err
+			}
+			return ethclient.NewClient(rc), nil
+		})
+		if errr != nil {
+			return nil, errr
+		}
+		if o.breaker != nil {
+			c.ethBreaker = NewCircuitBreaker(o.ethUrl, *o.breaker)
+		}
+	}
+
+	httpOpts := []HttpOption{WithHttpHeaderProvider(o.headers)}
+	if o.tlsConfig != nil {
+		httpOpts = append(httpOpts, WithHttpTLS(o.tlsConfig))
 	}
-	c.timeout = time.Duration(to) * time.Second
-	interval := int64(DefaultGetTxIntervalSeconds)
-	if interval > 0 {
-		interval = getTxIntervalSeconds
+	if o.httpUrl != "" {
+		c.http = NewHttpClient(o.httpUrl, 0, httpOpts...)
+		c.http.timeout = c.timeout
 	}
-	c.GetTxInterval = time.Duration(interval) * time.Second
 
-	c.eth, errr = _timeoutRun(cctx, c.timeout, func(ctx context.Context) (*ethclient.Client, error) {
-		return ethclient.DialContext(ctx, ethurl)
-	})
-	if errr != nil {
-		return nil, errr
+	dialOpts := []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+	if o.tlsConfig != nil {
+		dialOpts[0] = grpc.WithTransportCredentials(credentials.NewTLS(o.tlsConfig))
 	}
-	c.chainid, errr = _timeoutRun(cctx, c.timeout, func(ctx context.Context) (*big.Int, error) {
-		return c.eth.ChainID(ctx)
-	})
-	if errr != nil {
-		return nil, errr
+	if o.headers != nil {
+		dialOpts = append(dialOpts,
+			grpc.WithChainUnaryInterceptor(headerUnaryInterceptor(o.headers)),
+			grpc.WithChainStreamInterceptor(headerStreamInterceptor(o.headers)))
 	}
+	dialOpts = append(dialOpts, o.dialOptions...)
 
-	c.fullnodeConn, errr = _timeoutRun(cctx, c.timeout, func(ctx context.Context) (*grpc.ClientConn, error) {
-		return grpc.DialContext(ctx, grpcurl, grpc.WithTransportCredentials(insecure.NewCredentials()))
-	})
-	if errr != nil {
-		return nil, errr
+	var nodes []*fullnode
+	for _, url := range o.grpcUrls {
+		b, err := _timeoutRun(cctx, c.timeout, func(ctx context.Context) (*GrpcBackend, error) {
+			return DialGrpcBackend(ctx, url, dialOpts...)
+		})
+		if err != nil {
+			for _, n := range nodes {
+				_ = n.closer()
+			}
+			return nil, fmt.Errorf("dial %s failed: %w", url, err)
+		}
+		nodes = append(nodes, &fullnode{url: url, backend: b, closer: b.Close})
+	}
+	for _, nb := range o.backends {
+		nodes = append(nodes, &fullnode{url: nb.name, backend: nb.backend})
+	}
+	if len(nodes) == 0 && c.http != nil {
+		// only HTTP API available
+		nodes = append(nodes, &fullnode{url: c.httpUrl, backend: c.http})
+	}
+	if len(nodes) > 0 {
+		c.fullnodes = newNodePool(nodes, o.balancer, o.health)
+		c.fullnodes.limiter = o.limiter
+		c.fullnodes.setBreakers(o.breaker)
 	}
-	c.fullnodeGrpc = api.NewWalletClient(c.fullnodeConn)
 
-	c.http = NewHttpClient(httpurl, to)
+	var solidities []*fullnode
+	for _, url := range o.solidityGrpc {
+		b, err := _timeoutRun(cctx, c.timeout, func(ctx context.Context) (*GrpcSolidityBackend, error) {
+			return DialGrpcSolidityBackend(ctx, url, dialOpts...)
+		})
+		if err != nil {
+			for _, n := range solidities {
+				_ = n.closer()
+			}
+			return nil, fmt.Errorf("dial solidity %s failed: %w", url, err)
+		}
+		solidities = append(solidities, &fullnode{url: url, backend: readOnlyBackend{b}, closer: b.Close})
+	}
+	if len(solidities) == 0 && o.solidityHttp != "" {
+		sh := NewHttpClient(o.solidityHttp, 0, append(httpOpts, WithHttpSolidity())...)
+		sh.timeout = c.timeout
+		solidities = append(solidities, &fullnode{url: o.solidityHttp, backend: readOnlyBackend{sh}})
+	}
+	if len(solidities) > 0 {
+		c.solidity = newNodePool(solidities, o.balancer, o.health)
+		c.solidity.limiter = o.limiter
+		c.solidity.setBreakers(o.breaker)
+	}
+	if c.quorum > 0 && (c.fullnodes == nil || len(c.fullnodes.nodes) < c.quorum) {
+		return nil, fmt.Errorf("quorum of %d needs at least %d fullnode backends", c.quorum, c.quorum)
+	}
 
+	if errr = c.initChainId(cctx, o.chainID, o.expectedChainID); errr != nil {
+		return nil, errr
+	}
 	return c, nil
 }
 
+// initChainId gets chain id from JSON-RPC, or from the genesis block id (the last 4 bytes)
+// if only fullnode backends are available, which is the same as eth_chainId of the TRON JSON-RPC.
+// Nothing is fetched if the chain id is known, which is still checked against the expected one.
+func (c *TronClient) initChainId(cctx context.Context, known, expected *big.Int) error {
+	var err error
+	switch {
+	case known != nil:
+		c.chainid = new(big.Int).Set(known)
+	case c.eth != nil:
+		c.chainid, err = _ethRun(cctx, c, "ChainId", func(ctx context.Context, eth *ethclient.Client) (*big.Int, error) {
+			return eth.ChainID(ctx)
+		})
+	case c.fullnodes != nil:
+		c.chainid, err = _backendRun(cctx, c, "ChainId", func(ctx context.Context, b Backend) (*big.Int, error) {
+			genesis, err := b.GetBlock(ctx, "0", false)
+			if err != nil {
+				return nil, err
+			}
+			if genesis == nil || len(genesis.Blockid) < 4 {
+				return nil, errors.New("genesis block not found")
+			}
+			return new(big.Int).SetBytes(genesis.Blockid[len(genesis.Blockid)-4:]), nil
+		})
+	}
+	if err != nil {
+		return err
+	}
+	if expected != nil {
+		if c.chainid == nil {
+			c.chainid = new(big.Int).Set(expected)
+		} else if c.chainid.Cmp(expected) != 0 {
+			return fmt.Errorf("chain id mismatch, expecting %s but %s", expected, c.chainid)
+		}
+	}
+	return nil
+}
+
 func (c *TronClient) Close() (err error) {
-	if c.fullnodeConn != nil {
-		err = c.fullnodeConn.Close()
+	if c.fullnodes != nil {
+		err = c.fullnodes.Close()
+	}
+	if c.solidity != nil {
+		if serr := c.solidity.Close(); err == nil {
+			err = serr
+		}
 	}
 	if c.http != nil {
 		c.http.Close()
@@ -111,10 +283,34 @@ func (c *TronClient) Close() (err error) {
 	return
 }
 
+// ChainId returns nil if chain id is unknown
 func (c *TronClient) ChainId() *big.Int {
+	if c.chainid == nil {
+		return nil
+	}
 	return new(big.Int).Set(c.chainid)
 }
 
+// BreakerStates returns the circuit breaker states of all endpoints, empty if WithCircuitBreaker
+// is not given
+func (c *TronClient) BreakerStates() map[string]BreakerState {
+	ret := make(map[string]BreakerState)
+	for _, p := range []*nodePool{c.fullnodes, c.solidity} {
+		if p == nil {
+			continue
+		}
+		for _, n := range p.nodes {
+			if n.breaker != nil {
+				ret[n.url] = n.breaker.State()
+			}
+		}
+	}
+	if c.ethBreaker != nil {
+		ret[c.ethUrl] = c.ethBreaker.State()
+	}
+	return ret
+}
+
 func (c *TronClient) String() string {
 	if c == nil {
 		return "TronClient<nil>"
@@ -125,9 +321,11 @@ func (c *TronClient) String() string {
 	if c.http != nil {
 		buf.WriteString("(CONN)")
 	}
-	buf.WriteString(fmt.Sprintf(" GRPC:%s", c.grpcUrl))
-	if c.fullnodeGrpc != nil {
-		buf.WriteString("(CONN)")
+	if c.fullnodes != nil {
+		buf.WriteString(fmt.Sprintf(" Fullnodes(%s):%s", c.fullnodes.balancer, c.fullnodes.nodes))
+	}
+	if c.solidity != nil {
+		buf.WriteString(fmt.Sprintf(" Solidity:%s", c.solidity.nodes))
 	}
 	buf.WriteString(fmt.Sprintf(" ETH:%s", c.ethUrl))
 	if c.eth != nil {
@@ -138,33 +336,26 @@ func (c *TronClient) String() string {
 }
 
 func (c *TronClient) GetNextMaintenanceTime(cctx context.Context) (time.Time, error) {
-	return _timeoutRun(cctx, c.timeout, func(ctx context.Context) (time.Time, error) {
-		nm, err := c.fullnodeGrpc.GetNextMaintenanceTime(ctx, &api.EmptyMessage{})
-		if err != nil {
-			return time.Time{}, err
-		}
-		if nm == nil {
-			return time.Time{}, errors.New("empty maintenance time")
-		}
-		if nm.Num > 9999999999 {
-			return time.UnixMilli(nm.Num), nil
-		} else {
-			return time.Unix(nm.Num, 0), nil
-		}
+	return _backendRun(cctx, c, "GetNextMaintenanceTime", func(ctx context.Context, b Backend) (time.Time, error) {
+		return b.GetNextMaintenanceTime(ctx)
 	})
 }
 
+func witnessPermOf(ctx context.Context, b Backend, addr address.Address) (*WitnessPerm, error) {
+	acc, err := b.GetAccount(ctx, addr)
+	if err != nil {
+		return nil, err
+	}
+	ret := &WitnessPerm{OwnerAddr: addr}
+	if acc != nil && acc.WitnessPermission != nil && len(acc.WitnessPermission.Keys) > 0 {
+		ret.WitnessAddr = acc.WitnessPermission.Keys[0].Address
+	}
+	return ret, nil
+}
+
 func (c *TronClient) WitnessPermissions(ctx context.Context, addr address.Address) (*WitnessPerm, error) {
-	return _timeoutRun(ctx, c.timeout, func(cctx context.Context) (*WitnessPerm, error) {
-		acc, err := c.fullnodeGrpc.GetAccount(cctx, &core.Account{Address: addr})
-		if err != nil {
-			return nil, err
-		}
-		ret := &WitnessPerm{OwnerAddr: addr}
-		if acc != nil && acc.WitnessPermission != nil && len(acc.WitnessPermission.Keys) > 0 {
-			ret.WitnessAddr = acc.WitnessPermission.Keys[0].Address
-		}
-		return ret, nil
+	return _backendRun(ctx, c, "WitnessPermissions", func(cctx context.Context, b Backend) (*WitnessPerm, error) {
+		return witnessPermOf(cctx, b, addr)
 	})
 }
 
@@ -174,13 +365,8 @@ const (
 	MaintenanceTimeIntervalKey = "getMaintenanceTimeInterval"
 )
 
-func (c *TronClient) ListCommittees(ctx context.Context) ([]*WitnessPerm, error) {
-	witnesses, err := _timeoutRun(ctx, c.timeout, func(cctx context.Context) (*api.WitnessList, error) {
-		return c.fullnodeGrpc.ListWitnesses(cctx, &api.EmptyMessage{})
-	})
-	if err != nil {
-		return nil, err
-	}
+func committeesOf(ctx context.Context, witnesses *api.WitnessList,
+	permOf func(context.Context, address.Address) (*WitnessPerm, error)) ([]*WitnessPerm, error) {
 	if witnesses == nil || len(witnesses.Witnesses) == 0 {
 		return nil, errors.New("no witnesses found")
 	}
@@ -191,7 +377,7 @@ func (c *TronClient) ListCommittees(ctx context.Context) ([]*WitnessPerm, error)
 				return nil, fmt.Errorf("invalid address of (%d)witness:{Address:%x IsJobs:%t}", i, witness.Address, witness.IsJobs)
 			}
 			addr := address.Address(witness.Address)
-			wp, err := c.WitnessPermissions(ctx, witness.Address)
+			wp, err := permOf(ctx, witness.Address)
 			if err != nil {
 				return nil, fmt.Errorf("get permission for witness %s(%s) failed: %w", addr.Hex(), addr.String(), err)
 			}
@@ -201,9 +387,37 @@ func (c *TronClient) ListCommittees(ctx context.Context) ([]*WitnessPerm, error)
 	return wps, nil
 }
 
+// ListCommittees returns the permission addresses of the witnesses which are producing blocks.
+// In quorum mode, all the queries of one answer are sent to the same endpoint.
+func (c *TronClient) ListCommittees(ctx context.Context) ([]*WitnessPerm, error) {
+	if c.quorum > 0 {
+		return _quorumRun(ctx, c, "ListCommittees", hashWitnessPerms,
+			func(cctx context.Context, b Backend) ([]*WitnessPerm, error) {
+				witnesses, err := _timeoutRun(cctx, c.timeout, func(ctx context.Context) (*api.WitnessList, error) {
+					return b.ListWitnesses(ctx)
+				})
+				if err != nil {
+					return nil, err
+				}
+				return committeesOf(cctx, witnesses, func(ctx context.Context, addr address.Address) (*WitnessPerm, error) {
+					return _timeoutRun(ctx, c.timeout, func(tctx context.Context) (*WitnessPerm, error) {
+						return witnessPermOf(tctx, b, addr)
+					})
+				})
+			})
+	}
+	witnesses, err := _backendRun(ctx, c, "ListWitnesses", func(cctx context.Context, b Backend) (*api.WitnessList, error) {
+		return b.ListWitnesses(cctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return committeesOf(ctx, witnesses, c.WitnessPermissions)
+}
+
 func (c *TronClient) GetMaintenanceTimeInterval(cctx context.Context) (time.Duration, error) {
-	return _timeoutRun(cctx, c.timeout, func(ctx context.Context) (time.Duration, error) {
-		chainparams, err := c.fullnodeGrpc.GetChainParameters(ctx, &api.EmptyMessage{})
+	return _backendRun(cctx, c, "GetMaintenanceTimeInterval", func(ctx context.Context, b Backend) (time.Duration, error) {
+		chainparams, err := b.GetChainParameters(ctx)
 		if err != nil {
 			return 0, err
 		}
@@ -220,29 +434,33 @@ func (c *TronClient) GetMaintenanceTimeInterval(cctx context.Context) (time.Dura
 	})
 }
 
-func (c *TronClient) GetBlockHeader(cctx context.Context, num int64) (*api.BlockExtention, error) {
-	return _timeoutRun(cctx, c.timeout, func(ctx context.Context) (*api.BlockExtention, error) {
-		req := &api.BlockReq{
-			IdOrNum: fmt.Sprintf("%d", num),
-			Detail:  false,
-		}
-		return c.fullnodeGrpc.GetBlock(ctx, req)
-	})
+// GetBlockHeader gets the block without transactions, from solidity nodes if Confirmed is given
+func (c *TronClient) GetBlockHeader(cctx context.Context, num int64, cons ...Consistency) (*api.BlockExtention, error) {
+	return c.getBlock(cctx, "GetBlockHeader", num, false, cons)
 }
 
-func (c *TronClient) GetBlock(cctx context.Context, num int64) (*api.BlockExtention, error) {
-	return _timeoutRun(cctx, c.timeout, func(ctx context.Context) (*api.BlockExtention, error) {
-		req := &api.BlockReq{
-			IdOrNum: fmt.Sprintf("%d", num),
-			Detail:  true,
-		}
-		return c.fullnodeGrpc.GetBlock(ctx, req)
-	})
+// GetBlock gets the block with transactions, from solidity nodes if Confirmed is given
+func (c *TronClient) GetBlock(cctx context.Context, num int64, cons ...Consistency) (*api.BlockExtention, error) {
+	return c.getBlock(cctx, "GetBlock", num, true, cons)
+}
+
+func (c *TronClient) getBlock(cctx context.Context, method string, num int64, detail bool, cons []Consistency) (*api.BlockExtention, error) {
+	f := func(ctx context.Context, b ReadBackend) (*api.BlockExtention, error) {
+		return b.GetBlock(ctx, fmt.Sprintf("%d", num), detail)
+	}
+	if c.quorum > 0 && consistencyOf(cons) == Latest {
+		return _quorumRun(cctx, c, method, hashProto[*api.BlockExtention],
+			_withTimeout(c.timeout, func(ctx context.Context, b Backend) (*api.BlockExtention, error) {
+				return f(ctx, b)
+			}))
+	}
+	return _consistentRun(cctx, c, method, cons, f)
 }
 
-func (c *TronClient) GetNowBlock(cctx context.Context) (*api.BlockExtention, error) {
-	return _timeoutRun(cctx, c.timeout, func(ctx context.Context) (*api.BlockExtention, error) {
-		return c.fullnodeGrpc.GetNowBlock2(ctx, &api.EmptyMessage{})
+// GetNowBlock gets the latest block, or the latest solidified block if Confirmed is given
+func (c *TronClient) GetNowBlock(cctx context.Context, cons ...Consistency) (*api.BlockExtention, error) {
+	return _consistentRun(cctx, c, "GetNowBlock", cons, func(ctx context.Context, b ReadBackend) (*api.BlockExtention, error) {
+		return b.GetNowBlock(ctx)
 	})
 }
 
@@ -251,11 +469,8 @@ func (c *TronClient) GetBlocks(cctx context.Context, start, end int64) ([]*api.B
 	if start < 0 || end < 0 || start >= end {
 		return nil, errors.New("invalid range")
 	}
-	return _timeoutRun(cctx, c.timeout, func(ctx context.Context) ([]*api.BlockExtention, error) {
-		list, err := c.fullnodeGrpc.GetBlockByLimitNext2(ctx, &api.BlockLimit{
-			StartNum: start,
-			EndNum:   end,
-		})
+	return _backendRun(cctx, c, "GetBlocks", func(ctx context.Context, b Backend) ([]*api.BlockExtention, error) {
+		list, err := b.GetBlockByLimitNext(ctx, start, end)
 		if err != nil {
 			return nil, err
 		}
@@ -283,26 +498,36 @@ func (c *TronClient) FilterLogs(cctx context.Context, from, to int64, addr []byt
 		Addresses: []ethcommon.Address{ethcommon.BytesToAddress(addr)},
 		Topics:    tss,
 	}
-	return _timeoutRun(cctx, c.timeout, func(ctx context.Context) ([]types.Log, error) {
-		return c.eth.FilterLogs(ctx, query)
+	return _ethRun(cctx, c, "FilterLogs", func(ctx context.Context, eth *ethclient.Client) ([]types.Log, error) {
+		return eth.FilterLogs(ctx, query)
 	})
 }
 
-func (c *TronClient) GetTransactionById(cctx context.Context, txHash []byte) (*core.Transaction, error) {
-	return _timeoutRun(cctx, c.timeout, func(ctx context.Context) (*core.Transaction, error) {
-		return c.fullnodeGrpc.GetTransactionById(ctx, &api.BytesMessage{Value: txHash})
+// GetTransactionById gets the transaction, only the solidified one if Confirmed is given
+func (c *TronClient) GetTransactionById(cctx context.Context, txHash []byte, cons ...Consistency) (*core.Transaction, error) {
+	return _consistentRun(cctx, c, "GetTransactionById", cons, func(ctx context.Context, b ReadBackend) (*core.Transaction, error) {
+		return b.GetTransactionById(ctx, txHash)
 	})
 }
 
-func (c *TronClient) GetTransactionInfoById(cctx context.Context, txHash []byte) (*core.TransactionInfo, error) {
-	return _timeoutRun(cctx, c.timeout, func(ctx context.Context) (*core.TransactionInfo, error) {
-		return c.fullnodeGrpc.GetTransactionInfoById(ctx, &api.BytesMessage{Value: txHash})
-	})
+// GetTransactionInfoById gets the receipt of the transaction, only the solidified one if Confirmed is given
+func (c *TronClient) GetTransactionInfoById(cctx context.Context, txHash []byte, cons ...Consistency) (*core.TransactionInfo, error) {
+	f := func(ctx context.Context, b ReadBackend) (*core.TransactionInfo, error) {
+		return b.GetTransactionInfoById(ctx, txHash)
+	}
+	if c.quorum > 0 && consistencyOf(cons) == Latest {
+		return _quorumRun(cctx, c, "GetTransactionInfoById", hashProto[*core.TransactionInfo],
+			_withTimeout(c.timeout, func(ctx context.Context, b Backend) (*core.TransactionInfo, error) {
+				return f(ctx, b)
+			}))
+	}
+	return _consistentRun(cctx, c, "GetTransactionInfoById", cons, f)
 }
 
-func (c *TronClient) CallContract(cctx context.Context, from, contract address.Address, data []byte) (*api.TransactionExtention, error) {
-	txx, err := _timeoutRun(cctx, c.timeout, func(ctx context.Context) (*api.TransactionExtention, error) {
-		return c.fullnodeGrpc.TriggerConstantContract(ctx, &core.TriggerSmartContract{
+// CallContract calls the constant method of contract, on the solidified state if Confirmed is given
+func (c *TronClient) CallContract(cctx context.Context, from, contract address.Address, data []byte, cons ...Consistency) (*api.TransactionExtention, error) {
+	txx, err := _consistentRun(cctx, c, "CallContract", cons, func(ctx context.Context, b ReadBackend) (*api.TransactionExtention, error) {
+		return b.TriggerConstantContract(ctx, &core.TriggerSmartContract{
 			OwnerAddress:    from,
 			ContractAddress: contract,
 			CallValue:       0,
@@ -317,53 +542,203 @@ func (c *TronClient) CallContract(cctx context.Context, from, contract address.A
 	return txx, nil
 }
 
+// TriggerContract calls contract by signer with value in sun and data, and TRC10 token if given.
+// The fee limit is estimated if feeLimit is AutoFeeLimit, or the default of the fullnode is used
+// if it is not positive.
 func (c *TronClient) TriggerContract(cctx context.Context, feeLimit int64,
-	fromPriv []byte, contract address.Address, value int64, data []byte) (*api.TransactionExtention, error) {
-	privKey, err := BytesToPrivateKey(fromPriv)
-	if err != nil || privKey == nil {
-		return nil, errors.New("unknown private key")
+	signer Signer, contract address.Address, value int64, data []byte, token ...TokenValue) (*api.TransactionExtention, error) {
+	if signer == nil {
+		return nil, errors.New("nil signer")
 	}
-	ethfrom := crypto.PubkeyToAddress(privKey.PublicKey)
-	from := address.BytesToAddress(ethfrom[:])
+	var tv *TokenValue
+	if len(token) > 0 {
+		tv = &token[0]
+	}
+	return c.triggerContract(cctx, signer, contract, value, data, tv, &TxOptions{FeeLimit: feeLimit})
+}
+
+// triggerContract builds the transaction calling contract with data by the fullnode, then signs
+// and broadcasts it
+func (c *TronClient) triggerContract(cctx context.Context, signer Signer, contract address.Address, value int64,
+	data []byte, token *TokenValue, opts *TxOptions) (*api.TransactionExtention, error) {
+	txx, err := c.buildTriggerTx(cctx, signer.Address(), contract, value, data, token, opts)
+	if err != nil {
+		return nil, err
+	}
+	stx, err := c.signAndBroadcast(cctx, signer, txx.Transaction)
+	if stx == nil {
+		return nil, err
+	}
+	txx.Txid = stx.Txid
+	return txx, err
+}
+
+// buildTriggerTx builds the unsigned transaction of owner calling contract with data by the
+// fullnode, with the fee limit, memo and permission id of opts
+func (c *TronClient) buildTriggerTx(cctx context.Context, owner, contract address.Address, value int64,
+	data []byte, token *TokenValue, opts *TxOptions) (*api.TransactionExtention, error) {
 	tsc := &core.TriggerSmartContract{
-		OwnerAddress:    from[:],
+		OwnerAddress:    owner,
 		ContractAddress: contract[:],
 		CallValue:       value,
 		Data:            data,
-		CallTokenValue:  0,
-		TokenId:         0,
 	}
-	txx, err := _timeoutRun(cctx, c.timeout, func(ctx context.Context) (*api.TransactionExtention, error) {
-		return c.fullnodeGrpc.TriggerContract(ctx, tsc)
+	if token != nil {
+		tsc.TokenId, tsc.CallTokenValue = token.TokenId, token.Amount
+	}
+	if opts != nil && opts.FeeLimit == AutoFeeLimit {
+		margin := opts.FeeLimitMargin
+		if margin <= 0 {
+			margin = c.feeLimitMargin
+		}
+		feeLimit, err := c.EstimateFeeLimit(cctx, tsc, margin)
+		if err != nil {
+			return nil, fmt.Errorf("estimate fee limit: %w", err)
+		}
+		o := *opts
+		o.FeeLimit = feeLimit
+		opts = &o
+	}
+	txx, err := _backendRun(cctx, c, "TriggerContract", func(ctx context.Context, b Backend) (*api.TransactionExtention, error) {
+		return b.TriggerContract(ctx, tsc)
 	})
-	if txx.Result.Code > 0 {
+	if err != nil {
+		return nil, err
+	}
+	if txx == nil || txx.Transaction == nil || txx.Transaction.RawData == nil {
+		return nil, ErrInvalidTx
+	}
+	if txx.Result != nil && txx.Result.Code > 0 {
 		return nil, fmt.Errorf("%s", string(txx.Result.Message))
 	}
-	if feeLimit > 0 {
-		txx.Transaction.RawData.FeeLimit = feeLimit
+	if opts != nil {
+		if opts.FeeLimit > 0 {
+			txx.Transaction.RawData.FeeLimit = opts.FeeLimit
+		}
+		if len(opts.Memo) > 0 {
+			txx.Transaction.RawData.Data = opts.Memo
+		}
+		if opts.PermissionID != 0 {
+			if len(txx.Transaction.RawData.Contract) == 0 {
+				return nil, ErrInvalidTx
+			}
+			txx.Transaction.RawData.Contract[0].PermissionId = opts.PermissionID
+		}
 	}
 	txId, err := HashMessage(txx.Transaction.RawData)
 	if err != nil {
 		return nil, err
 	}
 	txx.Txid = txId
+	return txx, nil
+}
 
-	sig, err := crypto.Sign(txId, privKey)
-	if err != nil {
-		return nil, err
+// BroadcastTransaction broadcasts signed tx, and retries on the next fullnode endpoint if
+// the current one failed or refused for its own reason, such as SERVER_BUSY. With a retry
+// policy, it is broadcast again only if all endpoints refused it before processing, such as
+// for rate limiting. DUP_TRANSACTION_ERROR is taken as success once any endpoint may have
+// received the transaction, even in a previous attempt.
+func (c *TronClient) BroadcastTransaction(cctx context.Context, tx *core.Transaction) error {
+	if c.fullnodes == nil {
+		return TransportUnavailableError{Transport: TransportGRPC}
+	}
+	st := new(broadcastState)
+	for attempt := 1; ; attempt++ {
+		err := c.broadcast(cctx, tx, st)
+		if err == nil || c.retry == nil || attempt >= c.retry.MaxAttempts || cctx.Err() != nil || !st.refused {
+			return err
+		}
+		timer := time.NewTimer(c.retry.backoff(attempt, err))
+		select {
+		case <-cctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
 	}
-	txx.Transaction.Signature = append(txx.Transaction.Signature, sig)
+}
 
-	ret, err := _timeoutRun(cctx, c.timeout, func(ctx context.Context) (*api.Return, error) {
-		return c.fullnodeGrpc.BroadcastTransaction(ctx, txx.Transaction)
-	})
-	if err != nil {
-		return txx, err
+// broadcastState is kept across the attempts of BroadcastTransaction
+type broadcastState struct {
+	// delivered is set once an endpoint may have received the transaction
+	delivered bool
+	// refused is whether all endpoints tried by the last attempt refused it before processing
+	refused bool
+}
+
+// classify classifies err by the retry policy, or DefaultClassifier without one
+func (c *TronClient) classify(err error) ErrorClass {
+	if c.retry != nil {
+		return c.retry.Classify(err)
 	}
-	if err = (*TxReturn)(ret).Err(); err != nil {
-		return txx, fmt.Errorf("broadcast failed: %w", err)
+	return DefaultClassifier(err)
+}
+
+// refusedBeforeProcessing tells whether the endpoint refused the broadcast without
+// processing it, so that it is safe to be broadcast again
+func (c *TronClient) refusedBeforeProcessing(err error) bool {
+	return c.classify(err) == ClassRateLimited
+}
+
+func (c *TronClient) broadcast(cctx context.Context, tx *core.Transaction, st *broadcastState) error {
+	var err error
+	tried := false
+	st.refused = true
+	defer func() {
+		st.refused = st.refused && tried
+	}()
+	for _, n := range c.fullnodes.order(false) {
+		if err = n.breaker.Allow(); err != nil {
+			continue
+		}
+		if err = c.fullnodes.limiter.Wait(cctx, n.url, "BroadcastTransaction"); err != nil {
+			n.breaker.cancel()
+			st.refused = false
+			return err
+		}
+		tried = true
+		start := time.Now()
+		var ret *api.Return
+		ret, err = _timeoutRun(cctx, c.timeout, func(ctx context.Context) (*api.Return, error) {
+			return n.backend.BroadcastTransaction(ctx, tx)
+		})
+		failed := isTransportError(err)
+		n.record(failed, time.Since(start), c.fullnodes.health.Window)
+		n.breaker.Record(failed)
+		if err != nil {
+			if !c.refusedBeforeProcessing(err) {
+				st.refused = false
+				if failed {
+					st.delivered = true
+				}
+			}
+			if failed {
+				if cctx.Err() != nil {
+					return err
+				}
+				continue
+			}
+			return err
+		}
+		if ret != nil && st.delivered && ret.Code == api.Return_DUP_TRANSACTION_ERROR {
+			// accepted by an endpoint which failed to respond
+			return nil
+		}
+		if err = (*TxReturn)(ret).Err(); err != nil {
+			err = fmt.Errorf("broadcast failed: %w", err)
+			switch ret.Code {
+			case api.Return_SERVER_BUSY:
+				continue
+			case api.Return_NO_CONNECTION, api.Return_NOT_ENOUGH_EFFECTIVE_CONNECTION:
+				st.refused = false
+				continue
+			}
+			st.refused = false
+			return err
+		}
+		return nil
 	}
-	return txx, nil
+	return err
 }
 
 func (c *TronClient) ParseReturn(ret *api.Return) error {
@@ -374,8 +749,8 @@ func (c *TronClient) ParseReturn(ret *api.Return) error {
 }
 
 func (c *TronClient) TriggerContractResult(cctx context.Context, txId []byte) (*core.Transaction, error) {
-	tx, err := _timeoutRun(cctx, c.timeout, func(ctx context.Context) (*core.Transaction, error) {
-		return c.fullnodeGrpc.GetTransactionById(ctx, &api.BytesMessage{Value: txId})
+	tx, err := _backendRun(cctx, c, "GetTransactionById", func(ctx context.Context, b Backend) (*core.Transaction, error) {
+		return b.GetTransactionById(ctx, txId)
 	})
 	if err != nil {
 		return nil, err
@@ -409,14 +784,57 @@ func (c *TronClient) TryTxByHash(cctx context.Context, txId []byte) (*core.Trans
 	return nil, ErrTxNotFound
 }
 
+// WaitReceipt polls the receipt of the transaction every GetTxInterval until it is packed into
+// a block, or cctx is done. Receipt.Err is set if the transaction failed. It keeps polling only
+// if the receipt is not found or the query failed transiently, other errors are returned at once.
+func (c *TronClient) WaitReceipt(cctx context.Context, txId []byte, cons ...Consistency) (*Receipt, error) {
+	interval := c.GetTxInterval
+	if interval <= 0 {
+		interval = DefaultGetTxIntervalSeconds * time.Second
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		info, err := c.GetTransactionInfoById(cctx, txId, cons...)
+		if err == nil && info != nil && len(info.Id) > 0 {
+			return (*TxInfo)(info).ToReceipt()
+		}
+		if err != nil && !c.receiptPending(err) {
+			return nil, err
+		}
+		select {
+		case <-cctx.Done():
+			if err == nil {
+				err = ErrTxResultNotFound
+			}
+			return nil, fmt.Errorf("%w: %v", cctx.Err(), err)
+		case <-ticker.C:
+		}
+	}
+}
+
+// receiptPending tells whether err of getting the receipt means it may be got later
+func (c *TronClient) receiptPending(err error) bool {
+	if errors.Is(err, ErrTxNotFound) || errors.Is(err, ErrTxResultNotFound) || status.Code(err) == codes.NotFound {
+		return true
+	}
+	switch c.classify(err) {
+	case ClassTransient, ClassRateLimited:
+		return true
+	default:
+		return false
+	}
+}
+
 func (c *TronClient) GetContract(cctx context.Context, addr []byte) (*core.SmartContract, error) {
-	return _timeoutRun(cctx, c.timeout, func(ctx context.Context) (*core.SmartContract, error) {
-		return c.fullnodeGrpc.GetContract(ctx, &api.BytesMessage{Value: addr})
+	return _backendRun(cctx, c, "GetContract", func(ctx context.Context, b Backend) (*core.SmartContract, error) {
+		return b.GetContract(ctx, addr)
 	})
 }
 
-func (c *TronClient) GetAccount(cctx context.Context, addr []byte) (*core.Account, error) {
-	return _timeoutRun(cctx, c.timeout, func(ctx context.Context) (*core.Account, error) {
-		return c.fullnodeGrpc.GetAccount(ctx, &core.Account{Address: addr})
+// GetAccount gets the account, in the solidified state if Confirmed is given
+func (c *TronClient) GetAccount(cctx context.Context, addr []byte, cons ...Consistency) (*core.Account, error) {
+	return _consistentRun(cctx, c, "GetAccount", cons, func(ctx context.Context, b ReadBackend) (*core.Account, error) {
+		return b.GetAccount(ctx, addr)
 	})
 }
@@ -3,7 +3,15 @@ package go_tronsdk
 import (
 	"context"
 	"encoding/hex"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func TestTronClient_FilterLogs(t *testing.T) {
@@ -55,7 +63,8 @@ func TestTronClient_GetTransactionById(t *testing.T) {
 // 		_ = client.Close()
 // 	}()
 //
-// 	txx, err := client.TriggerContract(context.Background(), 10000000000, priv, cbs, 0, data)
+// 	signer, _ := NewPrivateKeySigner(priv)
+// 	txx, err := client.TriggerContract(context.Background(), 10000000000, signer, cbs, 0, data)
 // 	if err != nil {
 // 		t.Fatal(err)
 // 	}
@@ -179,3 +188,94 @@ func TestTronClient_GetAccount(t *testing.T) {
 	}
 	t.Log(acc)
 }
+
+func TestNewClient_GRPCOnly(t *testing.T) {
+	client, err := NewClient(context.Background(), WithGRPC("grpc.shasta.trongrid.io:50051"), WithTimeout(10*time.Second))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = client.Close()
+	}()
+	t.Log(client)
+	if _, err = client.FilterLogs(context.Background(), 0, 1, nil); !errors.Is(err, ErrTransportUnavailable) {
+		t.Fatalf("expecting ErrTransportUnavailable, got %v", err)
+	}
+}
+
+func TestNewClient_TransportUnavailable(t *testing.T) {
+	client, err := NewClient(context.Background(), WithBackend("fake", NewGrpcBackend(&fakeWallet{name: "fake"})))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = client.Close()
+	}()
+	if client.ChainId() == nil || client.ChainId().Uint64() != 0x2b6653dc {
+		t.Fatalf("chain id from genesis block expected, got %s", client.ChainId())
+	}
+	// the expected chain id is checked against the genesis block
+	if _, err = NewClient(context.Background(), WithBackend("fake", NewGrpcBackend(&fakeWallet{name: "fake"})),
+		WithExpectedChainID(big.NewInt(0xcd8690dc))); err == nil {
+		t.Fatal("expecting chain id mismatch")
+	}
+	// the genesis block is not fetched with the known chain id
+	down := &fakeWallet{name: "down", err: status.Error(codes.Unavailable, "down")}
+	known, err := NewClient(context.Background(), WithBackend("down", NewGrpcBackend(down)),
+		WithChainID(big.NewInt(0xcd8690dc)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = known.Close()
+	if known.ChainId().Uint64() != 0xcd8690dc {
+		t.Fatalf("known chain id expected, got %s", known.ChainId())
+	}
+	var tue TransportUnavailableError
+	if _, err = client.FilterLogs(context.Background(), 0, 1, nil); !errors.As(err, &tue) || tue.Transport != TransportJSONRPC {
+		t.Fatalf("expecting jsonrpc unavailable, got %v", err)
+	}
+	if _, err = NewClient(context.Background()); err == nil {
+		t.Fatal("expecting error without any transport")
+	}
+}
+
+func TestNewClient_Consistency(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		_, _ = w.Write([]byte(`{"account_name":"736f6c6964"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(context.Background(), WithBackend("fake", NewGrpcBackend(&fakeWallet{name: "fake"})))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = client.Close()
+	}()
+	var tue TransportUnavailableError
+	if _, err = client.GetAccount(context.Background(), nil, Confirmed); !errors.As(err, &tue) || tue.Transport != TransportSolidity {
+		t.Fatalf("expecting solidity unavailable, got %v", err)
+	}
+
+	client2, err := NewClient(context.Background(), WithBackend("fake", NewGrpcBackend(&fakeWallet{name: "fake"})),
+		WithSolidityHTTP(server.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = client2.Close()
+	}()
+	acc, err := client2.GetAccount(context.Background(), nil)
+	if err != nil || string(acc.AccountName) != "fake" {
+		t.Fatalf("Latest should be answered by fullnode, got %v %v", acc, err)
+	}
+	acc, err = client2.GetAccount(context.Background(), nil, Confirmed)
+	if err != nil || string(acc.AccountName) != "solid" {
+		t.Fatalf("Confirmed should be answered by solidity node, got %v %v", acc, err)
+	}
+	if len(paths) != 1 || paths[0] != "/walletsolidity/getaccount" {
+		t.Fatalf("unexpected requests %v", paths)
+	}
+}
@@ -0,0 +1,345 @@
+package go_tronsdk
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+)
+
+const (
+	// BlockInterval is the producing interval of TRON blocks, lock periods are counted in blocks
+	BlockInterval = 3 * time.Second
+	// MinDelegateAmount is the min amount of a DelegateResource, 1 TRX
+	MinDelegateAmount = 1_000_000
+)
+
+var ErrInsufficientDelegatable = errors.New("insufficient delegatable balance")
+
+// LockPeriod converts d to the lock period of DelegateResource in blocks, rounded up
+func LockPeriod(d time.Duration) int64 {
+	if d <= 0 {
+		return 0
+	}
+	return int64((d + BlockInterval - 1) / BlockInterval)
+}
+
+// Delegation is the resource delegated from From to To by staking Amount sun
+type Delegation struct {
+	From     address.Address
+	To       address.Address
+	Resource core.ResourceCode
+	Amount   int64
+	// ExpireTime is when the lock expires, zero if not locked. It could not be undelegated before.
+	ExpireTime time.Time
+}
+
+func (d *Delegation) Locked(now time.Time) bool {
+	return d.ExpireTime.After(now)
+}
+
+func (d *Delegation) String() string {
+	if d.ExpireTime.IsZero() {
+		return fmt.Sprintf("{%s->%s %s %d}", d.From, d.To, d.Resource, d.Amount)
+	}
+	return fmt.Sprintf("{%s->%s %s %d expire:%s}", d.From, d.To, d.Resource, d.Amount, d.ExpireTime.Format(time.RFC3339))
+}
+
+func expireTime(ms int64) time.Time {
+	if ms <= 0 {
+		return time.Time{}
+	}
+	return time.UnixMilli(ms)
+}
+
+// ParseDelegations flattens the records of GetDelegatedResourceV2 by resource. The locked and
+// unlocked parts of a delegation are separate records.
+func ParseDelegations(list *api.DelegatedResourceList) []*Delegation {
+	var ret []*Delegation
+	for _, r := range list.GetDelegatedResource() {
+		if r == nil {
+			continue
+		}
+		if r.FrozenBalanceForBandwidth > 0 {
+			ret = append(ret, &Delegation{From: r.From, To: r.To, Resource: core.ResourceCode_BANDWIDTH,
+				Amount: r.FrozenBalanceForBandwidth, ExpireTime: expireTime(r.ExpireTimeForBandwidth)})
+		}
+		if r.FrozenBalanceForEnergy > 0 {
+			ret = append(ret, &Delegation{From: r.From, To: r.To, Resource: core.ResourceCode_ENERGY,
+				Amount: r.FrozenBalanceForEnergy, ExpireTime: expireTime(r.ExpireTimeForEnergy)})
+		}
+	}
+	return ret
+}
+
+func (c *TronClient) GetDelegatedResourceV2(ctx context.Context, from, to address.Address, cons ...Consistency) (*api.DelegatedResourceList, error) {
+	return _consistentRun(ctx, c, "GetDelegatedResourceV2", cons, func(ctx context.Context, b ReadBackend) (*api.DelegatedResourceList, error) {
+		return b.GetDelegatedResourceV2(ctx, from, to)
+	})
+}
+
+func (c *TronClient) GetDelegatedResourceAccountIndexV2(ctx context.Context, addr address.Address, cons ...Consistency) (*core.DelegatedResourceAccountIndex, error) {
+	return _consistentRun(ctx, c, "GetDelegatedResourceAccountIndexV2", cons, func(ctx context.Context, b ReadBackend) (*core.DelegatedResourceAccountIndex, error) {
+		return b.GetDelegatedResourceAccountIndexV2(ctx, addr)
+	})
+}
+
+// GetCanDelegatedMaxSize returns the max amount in sun which owner could delegate for resource
+func (c *TronClient) GetCanDelegatedMaxSize(ctx context.Context, owner address.Address, resource core.ResourceCode, cons ...Consistency) (int64, error) {
+	ret, err := _consistentRun(ctx, c, "GetCanDelegatedMaxSize", cons, func(ctx context.Context, b ReadBackend) (*api.CanDelegatedMaxSizeResponseMessage, error) {
+		return b.GetCanDelegatedMaxSize(ctx, owner, resource)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return ret.GetMaxSize(), nil
+}
+
+// Delegations gets the resources delegated from one account to another
+func (c *TronClient) Delegations(ctx context.Context, from, to address.Address, cons ...Consistency) ([]*Delegation, error) {
+	list, err := c.GetDelegatedResourceV2(ctx, from, to, cons...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDelegations(list), nil
+}
+
+func checkDelegateResource(resource core.ResourceCode) error {
+	switch resource {
+	case core.ResourceCode_BANDWIDTH, core.ResourceCode_ENERGY:
+		return nil
+	default:
+		return fmt.Errorf("resource %s could not be delegated", resource)
+	}
+}
+
+func checkDelegate(signer Signer, receiver address.Address, amountSun int64, resource core.ResourceCode) error {
+	if signer == nil {
+		return errors.New("nil signer")
+	}
+	if !receiver.IsValid() {
+		return errors.New("invalid receiver address")
+	}
+	if bytes.Equal(signer.Address(), receiver) {
+		return errors.New("cannot delegate to self")
+	}
+	if amountSun <= 0 {
+		return errors.New("amount should be positive")
+	}
+	return checkDelegateResource(resource)
+}
+
+// DelegateResource delegates the resource of amountSun staked by the signer to receiver. A locked
+// delegation could not be undelegated in lockPeriod blocks (see LockPeriod), or the default
+// period of the chain (3 days) if lockPeriod is 0.
+func (c *TronClient) DelegateResource(ctx context.Context, signer Signer, receiver address.Address, amountSun int64,
+	resource core.ResourceCode, lock bool, lockPeriod int64, opts *TxOptions) (*api.TransactionExtention, error) {
+	if err := checkDelegate(signer, receiver, amountSun, resource); err != nil {
+		return nil, err
+	}
+	if amountSun < MinDelegateAmount {
+		return nil, fmt.Errorf("amount should be at least %d", MinDelegateAmount)
+	}
+	if lockPeriod < 0 || (lockPeriod > 0 && !lock) {
+		return nil, fmt.Errorf("invalid lock period %d", lockPeriod)
+	}
+	return c.sendContract(ctx, signer, core.Transaction_Contract_DelegateResourceContract, &core.DelegateResourceContract{
+		OwnerAddress:    signer.Address(),
+		Resource:        resource,
+		Balance:         amountSun,
+		ReceiverAddress: receiver,
+		Lock:            lock,
+		LockPeriod:      lockPeriod,
+	}, opts)
+}
+
+// UnDelegateResource takes back the resource of amountSun delegated to receiver, which must not
+// be locked
+func (c *TronClient) UnDelegateResource(ctx context.Context, signer Signer, receiver address.Address, amountSun int64,
+	resource core.ResourceCode, opts *TxOptions) (*api.TransactionExtention, error) {
+	if err := checkDelegate(signer, receiver, amountSun, resource); err != nil {
+		return nil, err
+	}
+	return c.sendContract(ctx, signer, core.Transaction_Contract_UnDelegateResourceContract, &core.UnDelegateResourceContract{
+		OwnerAddress:    signer.Address(),
+		Resource:        resource,
+		Balance:         amountSun,
+		ReceiverAddress: receiver,
+	}, opts)
+}
+
+// DelegationChange is a transaction to be submitted by DelegationReconciler
+type DelegationChange struct {
+	Receiver address.Address
+	Delegate bool // false for undelegate
+	Amount   int64
+}
+
+func (c *DelegationChange) String() string {
+	if c.Delegate {
+		return fmt.Sprintf("{delegate %d to %s}", c.Amount, c.Receiver)
+	}
+	return fmt.Sprintf("{undelegate %d from %s}", c.Amount, c.Receiver)
+}
+
+// DelegationReconciler makes the delegations of Resource from Signer match the desired amounts
+// with the fewest transactions, one for each receiver at most.
+type DelegationReconciler struct {
+	Client   *TronClient
+	Signer   Signer
+	Resource core.ResourceCode
+	// Lock and LockPeriod are used by the delegations, see DelegateResource
+	Lock       bool
+	LockPeriod int64
+	// Tolerance is the difference in sun ignored between the desired and the current amount
+	Tolerance int64
+	// Prune undelegates the receivers not in the desired map, otherwise they are left as is
+	Prune bool
+	Opts  *TxOptions
+}
+
+// Plan computes the changes for desired, which maps the base58 address of receivers to the
+// amounts in sun. Undelegations come first so that the returned balance could be delegated
+// again. Locked delegations are not undelegated until they expire, and the increases less
+// than MinDelegateAmount are ignored as they could not be delegated.
+func (r *DelegationReconciler) Plan(ctx context.Context, desired map[string]int64) ([]*DelegationChange, error) {
+	if r.Client == nil || r.Signer == nil {
+		return nil, errors.New("client and signer are required")
+	}
+	if err := checkDelegateResource(r.Resource); err != nil {
+		return nil, err
+	}
+	owner := r.Signer.Address()
+	targets := make(map[string]int64, len(desired))
+	for k, amount := range desired {
+		addr, err := address.Base58ToAddress(k)
+		if err != nil {
+			return nil, fmt.Errorf("invalid receiver %s: %w", k, err)
+		}
+		if amount < 0 {
+			return nil, fmt.Errorf("negative amount %d of %s", amount, k)
+		}
+		targets[addr.String()] = amount
+	}
+
+	index, err := r.Client.GetDelegatedResourceAccountIndexV2(ctx, owner)
+	if err != nil {
+		return nil, fmt.Errorf("delegated accounts: %w", err)
+	}
+	receivers := make(map[string]address.Address)
+	for _, to := range index.GetToAccounts() {
+		a := address.Address(to)
+		if _, ok := targets[a.String()]; ok || r.Prune {
+			receivers[a.String()] = a
+		}
+	}
+	now := time.Now()
+	var undelegates, delegates []*DelegationChange
+	for k, amount := range targets {
+		receiver, _ := address.Base58ToAddress(k)
+		var current, unlocked int64
+		if _, ok := receivers[k]; ok {
+			ds, err := r.Client.Delegations(ctx, owner, receiver)
+			if err != nil {
+				return nil, fmt.Errorf("delegations to %s: %w", k, err)
+			}
+			current, unlocked = sumDelegations(ds, r.Resource, now)
+			delete(receivers, k)
+		}
+		switch diff := amount - current; {
+		case diff > r.Tolerance && diff >= MinDelegateAmount:
+			delegates = append(delegates, &DelegationChange{Receiver: receiver, Delegate: true, Amount: diff})
+		case -diff > r.Tolerance && unlocked > 0:
+			if -diff < unlocked {
+				unlocked = -diff
+			}
+			undelegates = append(undelegates, &DelegationChange{Receiver: receiver, Amount: unlocked})
+		}
+	}
+	for k, receiver := range receivers {
+		// only the pruned ones left
+		ds, err := r.Client.Delegations(ctx, owner, receiver)
+		if err != nil {
+			return nil, fmt.Errorf("delegations to %s: %w", k, err)
+		}
+		if _, unlocked := sumDelegations(ds, r.Resource, now); unlocked > 0 {
+			undelegates = append(undelegates, &DelegationChange{Receiver: receiver, Amount: unlocked})
+		}
+	}
+	sortChanges(undelegates)
+	sortChanges(delegates)
+
+	if len(delegates) > 0 {
+		var need, freed int64
+		for _, d := range delegates {
+			need += d.Amount
+		}
+		for _, u := range undelegates {
+			freed += u.Amount
+		}
+		available, err := r.Client.GetCanDelegatedMaxSize(ctx, owner, r.Resource)
+		if err != nil {
+			return nil, fmt.Errorf("delegatable balance: %w", err)
+		}
+		if need > available+freed {
+			return nil, fmt.Errorf("%w: need %d, available %d", ErrInsufficientDelegatable, need, available+freed)
+		}
+	}
+	return append(undelegates, delegates...), nil
+}
+
+// Apply submits the changes in order and stops at the first failure. The transactions submitted
+// are returned, including the failed one if it has been signed.
+func (r *DelegationReconciler) Apply(ctx context.Context, changes []*DelegationChange) ([]*api.TransactionExtention, error) {
+	var txs []*api.TransactionExtention
+	for _, change := range changes {
+		var txx *api.TransactionExtention
+		var err error
+		if change.Delegate {
+			txx, err = r.Client.DelegateResource(ctx, r.Signer, change.Receiver, change.Amount, r.Resource, r.Lock, r.LockPeriod, r.Opts)
+		} else {
+			txx, err = r.Client.UnDelegateResource(ctx, r.Signer, change.Receiver, change.Amount, r.Resource, r.Opts)
+		}
+		if txx != nil {
+			txs = append(txs, txx)
+		}
+		if err != nil {
+			return txs, fmt.Errorf("%s: %w", change, err)
+		}
+	}
+	return txs, nil
+}
+
+// Reconcile plans and applies the changes for desired
+func (r *DelegationReconciler) Reconcile(ctx context.Context, desired map[string]int64) ([]*api.TransactionExtention, error) {
+	changes, err := r.Plan(ctx, desired)
+	if err != nil {
+		return nil, err
+	}
+	return r.Apply(ctx, changes)
+}
+
+func sumDelegations(ds []*Delegation, resource core.ResourceCode, now time.Time) (total, unlocked int64) {
+	for _, d := range ds {
+		if d.Resource != resource {
+			continue
+		}
+		total += d.Amount
+		if !d.Locked(now) {
+			unlocked += d.Amount
+		}
+	}
+	return total, unlocked
+}
+
+func sortChanges(changes []*DelegationChange) {
+	sort.Slice(changes, func(i, j int) bool {
+		return bytes.Compare(changes[i].Receiver, changes[j].Receiver) < 0
+	})
+}
@@ -0,0 +1,141 @@
+package go_tronsdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"google.golang.org/grpc"
+)
+
+type delegationWallet struct {
+	*fakeWallet
+	delegated map[string][]*core.DelegatedResource // by receiver
+	maxSize   int64
+}
+
+func (w *delegationWallet) GetDelegatedResourceAccountIndexV2(_ context.Context, in *api.BytesMessage, _ ...grpc.CallOption) (*core.DelegatedResourceAccountIndex, error) {
+	index := &core.DelegatedResourceAccountIndex{Account: in.Value}
+	for k := range w.delegated {
+		to, _ := address.Base58ToAddress(k)
+		index.ToAccounts = append(index.ToAccounts, to)
+	}
+	return index, nil
+}
+
+func (w *delegationWallet) GetDelegatedResourceV2(_ context.Context, in *api.DelegatedResourceMessage, _ ...grpc.CallOption) (*api.DelegatedResourceList, error) {
+	return &api.DelegatedResourceList{DelegatedResource: w.delegated[address.Address(in.ToAddress).String()]}, nil
+}
+
+func (w *delegationWallet) GetCanDelegatedMaxSize(_ context.Context, _ *api.CanDelegatedMaxSizeRequestMessage, _ ...grpc.CallOption) (*api.CanDelegatedMaxSizeResponseMessage, error) {
+	return &api.CanDelegatedMaxSizeResponseMessage{MaxSize: w.maxSize}, nil
+}
+
+func TestDelegationReconciler(t *testing.T) {
+	receiver := func(b byte) address.Address {
+		return append([]byte{address.TronBytePrefix}, bytes.Repeat([]byte{b}, 20)...)
+	}
+	a, b, c, d := receiver(1), receiver(2), receiver(3), receiver(4)
+	locked := time.Now().Add(time.Hour).UnixMilli()
+	w := &delegationWallet{fakeWallet: &fakeWallet{name: "fake", height: 100}, maxSize: 3_000_000,
+		delegated: map[string][]*core.DelegatedResource{
+			a.String(): {{To: a, FrozenBalanceForEnergy: 5_000_000, FrozenBalanceForBandwidth: 9_000_000}},
+			b.String(): {
+				{To: b, FrozenBalanceForEnergy: 4_000_000},
+				{To: b, FrozenBalanceForEnergy: 6_000_000, ExpireTimeForEnergy: locked},
+			},
+			d.String(): {{To: d, FrozenBalanceForEnergy: 1_000_000}},
+		}}
+	client, err := NewClient(context.Background(), WithBackend("fake", NewGrpcBackend(w)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = client.Close()
+	}()
+	priv, _ := hex.DecodeString("4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318")
+	signer, _ := NewPrivateKeySigner(priv)
+
+	r := &DelegationReconciler{Client: client, Signer: signer, Resource: core.ResourceCode_ENERGY, Tolerance: 100, Prune: true}
+	desired := map[string]int64{a.String(): 8_000_000, b.String(): 0, c.String(): 2_000_000}
+	changes, err := r.Plan(context.Background(), desired)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []*DelegationChange{
+		{Receiver: b, Amount: 4_000_000},
+		{Receiver: d, Amount: 1_000_000},
+		{Receiver: a, Delegate: true, Amount: 3_000_000},
+		{Receiver: c, Delegate: true, Amount: 2_000_000},
+	}
+	if len(changes) != len(want) {
+		t.Fatalf("expecting %v, got %v", want, changes)
+	}
+	for i := range want {
+		if changes[i].String() != want[i].String() {
+			t.Fatalf("expecting %v, got %v", want, changes)
+		}
+	}
+
+	txs, err := r.Apply(context.Background(), changes)
+	if err != nil || len(txs) != 4 || len(w.broadcasts) != 4 {
+		t.Fatalf("expecting 4 transactions, got %d %v", len(txs), err)
+	}
+	dc := new(core.DelegateResourceContract)
+	if err = txs[2].Transaction.RawData.Contract[0].Parameter.UnmarshalTo(dc); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(dc.ReceiverAddress, a) || dc.Balance != 3_000_000 || dc.Resource != core.ResourceCode_ENERGY {
+		t.Fatalf("unexpected contract %v", dc)
+	}
+
+	r.Prune = false
+	desired[a.String()] = 8_000_050
+	if changes, err = r.Plan(context.Background(), desired); err != nil || len(changes) != 3 {
+		t.Fatalf("expecting 3 changes, got %v %v", changes, err)
+	}
+	w.maxSize = 0
+	if _, err = r.Plan(context.Background(), desired); !errors.Is(err, ErrInsufficientDelegatable) {
+		t.Fatalf("expecting insufficient delegatable, got %v", err)
+	}
+}
+
+func TestTronClient_DelegateResource(t *testing.T) {
+	client, err := NewClient(context.Background(), WithBackend("fake", NewGrpcBackend(&fakeWallet{name: "fake", height: 100})))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = client.Close()
+	}()
+	priv, _ := hex.DecodeString("4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318")
+	signer, _ := NewPrivateKeySigner(priv)
+	to, _ := hex.DecodeString(testToHex)
+
+	txx, err := client.DelegateResource(context.Background(), signer, to, 2_000_000, core.ResourceCode_BANDWIDTH, true, LockPeriod(time.Hour), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dc := new(core.DelegateResourceContract)
+	if err = txx.Transaction.RawData.Contract[0].Parameter.UnmarshalTo(dc); err != nil {
+		t.Fatal(err)
+	}
+	if !dc.Lock || dc.LockPeriod != 1200 || !bytes.Equal(dc.OwnerAddress, signer.Address()) {
+		t.Fatalf("unexpected contract %v", dc)
+	}
+	if _, err = client.DelegateResource(context.Background(), signer, to, 2_000_000, core.ResourceCode_TRON_POWER, false, 0, nil); err == nil {
+		t.Fatal("tron power could not be delegated")
+	}
+	if _, err = client.DelegateResource(context.Background(), signer, to, 2_000_000, core.ResourceCode_ENERGY, false, 100, nil); err == nil {
+		t.Fatal("lock period without lock should be invalid")
+	}
+	if _, err = client.UnDelegateResource(context.Background(), signer, signer.Address(), 1, core.ResourceCode_ENERGY, nil); err == nil {
+		t.Fatal("expecting self undelegation error")
+	}
+}
@@ -0,0 +1,103 @@
+package go_tronsdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// AutoFeeLimit as the fee limit of a contract call estimates it by the energy of the call and
+	// the energy price, with a safety margin
+	AutoFeeLimit int64 = -1
+	// DefaultFeeLimitMargin is 20% more than the estimated fee
+	DefaultFeeLimitMargin = 0.2
+
+	MaxFeeLimitKey = "getMaxFeeLimit"
+)
+
+// EstimateEnergy estimates the energy used by the call with the EstimateEnergy API, or by
+// TriggerConstantContract if the API is not supported or enabled by the node. Other errors,
+// such as reverts, are returned as they are. The EnergyUsed of a constant
+// call includes its EnergyPenalty already.
+func (c *TronClient) EstimateEnergy(cctx context.Context, tsc *core.TriggerSmartContract, cons ...Consistency) (int64, error) {
+	if tsc == nil {
+		return 0, errors.New("nil contract call")
+	}
+	est, err := _consistentRun(cctx, c, "EstimateEnergy", cons, func(ctx context.Context, b ReadBackend) (int64, error) {
+		ret, err := b.EstimateEnergy(ctx, tsc)
+		if err != nil {
+			return 0, err
+		}
+		if err = (*TxReturn)(ret.GetResult()).Err(); err != nil {
+			return 0, err
+		}
+		return ret.EnergyRequired, nil
+	})
+	if err == nil || !estimateUnsupported(err) {
+		return est, err
+	}
+	txx, err := _consistentRun(cctx, c, "TriggerConstantContract", cons, func(ctx context.Context, b ReadBackend) (*api.TransactionExtention, error) {
+		return b.TriggerConstantContract(ctx, tsc)
+	})
+	if err != nil {
+		return 0, err
+	}
+	if err = (*TxReturn)(txx.GetResult()).Err(); err != nil {
+		return 0, err
+	}
+	if tx := txx.GetTransaction(); tx != nil && len(tx.Ret) > 0 {
+		if _, err = (*Tx)(tx).ToResult(); err != nil {
+			return 0, err
+		}
+	}
+	return txx.EnergyUsed, nil
+}
+
+// estimateUnsupported reports whether err is of a node not supporting or enabling EstimateEnergy
+func estimateUnsupported(err error) bool {
+	if status.Code(err) == codes.Unimplemented {
+		return true
+	}
+	var he HTTPError
+	if errors.As(err, &he) && (he.StatusCode == http.StatusNotFound || he.StatusCode == http.StatusMethodNotAllowed) {
+		return true
+	}
+	return strings.Contains(err.Error(), "does not support estimate energy")
+}
+
+// EstimateFeeLimit estimates the fee limit of the call in sun, which is the energy estimated
+// times the energy price of the chain, plus margin (0.2 for 20%), and capped by the max fee limit
+// of the chain.
+func (c *TronClient) EstimateFeeLimit(cctx context.Context, tsc *core.TriggerSmartContract, margin float64) (int64, error) {
+	if margin < 0 {
+		return 0, fmt.Errorf("invalid margin %f", margin)
+	}
+	energy, err := c.EstimateEnergy(cctx, tsc)
+	if err != nil {
+		return 0, err
+	}
+	params, err := c.GetChainParameters(cctx)
+	if err != nil {
+		return 0, err
+	}
+	price, err := chainParameterOf(params, EnergyFeeKey)
+	if err != nil {
+		return 0, err
+	}
+	// margin in basis points avoids the rounding of float
+	bp := 10000 + int64(math.Round(margin*10000))
+	feeLimit := (energy*price*bp + 9999) / 10000
+	if maxFee, err := chainParameterOf(params, MaxFeeLimitKey); err == nil && maxFee > 0 && feeLimit > maxFee {
+		feeLimit = maxFee
+	}
+	return feeLimit, nil
+}
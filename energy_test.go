@@ -0,0 +1,101 @@
+package go_tronsdk
+
+import (
+	"context"
+	"encoding/hex"
+	"testing"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type energyWallet struct {
+	*fakeWallet
+	estimate    bool
+	estimateErr error
+	revert      bool
+	constants   int
+}
+
+func (w *energyWallet) EstimateEnergy(_ context.Context, _ *core.TriggerSmartContract, _ ...grpc.CallOption) (*api.EstimateEnergyMessage, error) {
+	switch {
+	case w.estimateErr != nil:
+		return nil, w.estimateErr
+	case w.revert:
+		return &api.EstimateEnergyMessage{Result: &api.Return{Code: api.Return_CONTRACT_EXE_ERROR,
+			Message: []byte("REVERT opcode executed")}}, nil
+	case !w.estimate:
+		return &api.EstimateEnergyMessage{Result: &api.Return{Code: api.Return_OTHER_ERROR,
+			Message: []byte("this node does not support estimate energy")}}, nil
+	}
+	return &api.EstimateEnergyMessage{Result: &api.Return{Result: true}, EnergyRequired: 30_000}, nil
+}
+
+func (w *energyWallet) TriggerConstantContract(_ context.Context, _ *core.TriggerSmartContract, _ ...grpc.CallOption) (*api.TransactionExtention, error) {
+	w.constants++
+	return &api.TransactionExtention{Result: &api.Return{Result: true}, EnergyUsed: 32_000, EnergyPenalty: 2_000}, nil
+}
+
+func (w *energyWallet) TriggerContract(_ context.Context, _ *core.TriggerSmartContract, _ ...grpc.CallOption) (*api.TransactionExtention, error) {
+	return &api.TransactionExtention{Result: &api.Return{Result: true},
+		Transaction: &core.Transaction{RawData: &core.TransactionRaw{FeeLimit: 1}}}, nil
+}
+
+func (w *energyWallet) GetChainParameters(_ context.Context, _ *api.EmptyMessage, _ ...grpc.CallOption) (*core.ChainParameters, error) {
+	return &core.ChainParameters{ChainParameter: []*core.ChainParameters_ChainParameter{
+		{Key: EnergyFeeKey, Value: 420},
+		{Key: MaxFeeLimitKey, Value: 15_000_000_000},
+	}}, nil
+}
+
+func TestTronClient_EstimateFeeLimit(t *testing.T) {
+	w := &energyWallet{fakeWallet: &fakeWallet{name: "fake", height: 100}}
+	client, err := NewClient(context.Background(), WithBackend("fake", NewGrpcBackend(w)), WithFeeLimitMargin(0.5))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = client.Close()
+	}()
+	contract, _ := hex.DecodeString(testToHex)
+	owner, _ := hex.DecodeString(testOwnerHex)
+	tsc := &core.TriggerSmartContract{OwnerAddress: owner, ContractAddress: contract}
+
+	if energy, err := client.EstimateEnergy(context.Background(), tsc); err != nil || energy != 32_000 {
+		t.Fatalf("expecting energy of constant call, got %d %v", energy, err)
+	}
+	w.estimateErr = status.Error(codes.Unimplemented, "unknown method EstimateEnergy")
+	if energy, err := client.EstimateEnergy(context.Background(), tsc); err != nil || energy != 32_000 || w.constants != 2 {
+		t.Fatalf("expecting energy of constant call, got %d %v", energy, err)
+	}
+	// reverts are not retried by the constant call
+	w.estimateErr, w.revert = nil, true
+	if _, err := client.EstimateEnergy(context.Background(), tsc); err == nil || w.constants != 2 {
+		t.Fatalf("expecting the revert returned, got %v after %d constant calls", err, w.constants)
+	}
+	w.estimate, w.revert = true, false
+	if energy, err := client.EstimateEnergy(context.Background(), tsc); err != nil || energy != 30_000 {
+		t.Fatalf("expecting estimated energy, got %d %v", energy, err)
+	}
+	if fee, err := client.EstimateFeeLimit(context.Background(), tsc, 0.1); err != nil || fee != 13_860_000 {
+		t.Fatalf("expecting 13.86 TRX, got %d %v", fee, err)
+	}
+
+	priv, _ := hex.DecodeString("4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318")
+	signer, _ := NewPrivateKeySigner(priv)
+	txx, err := client.TriggerContract(context.Background(), AutoFeeLimit, signer, contract, 0, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if txx.Transaction.RawData.FeeLimit != 18_900_000 {
+		t.Fatalf("expecting fee limit with the margin of client, got %d", txx.Transaction.RawData.FeeLimit)
+	}
+	opts := &TxOptions{FeeLimit: AutoFeeLimit, FeeLimitMargin: 0.2}
+	if txx, err = client.triggerContract(context.Background(), signer, contract, 0, nil, nil, opts); err != nil ||
+		txx.Transaction.RawData.FeeLimit != 15_120_000 || opts.FeeLimit != AutoFeeLimit {
+		t.Fatalf("expecting fee limit with the margin of options, got %d %v", txx.Transaction.RawData.FeeLimit, err)
+	}
+}
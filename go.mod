@@ -5,6 +5,7 @@ go 1.20.0
 require (
 	github.com/ethereum/go-ethereum v1.13.5
 	github.com/fbsobreira/gotron-sdk v0.0.0-00010101000000-000000000000
+	github.com/tyler-smith/go-bip39 v1.1.0
 	google.golang.org/grpc v1.59.0
 	google.golang.org/protobuf v1.31.0
 )
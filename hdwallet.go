@@ -0,0 +1,118 @@
+package go_tronsdk
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/crypto/secp256k1"
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/keys/hd"
+	"github.com/tyler-smith/go-bip39"
+)
+
+const (
+	// TronCoinType is the BIP44 coin type of TRON
+	TronCoinType = 195
+	// TronHDPathFormat is the BIP44 path of TRON keys: m/44'/195'/account'/0/index
+	TronHDPathFormat = "44'/195'/%d'/0/%d"
+
+	maxHDIndex = 1<<31 - 1 // higher indexes are for hardened derivation
+)
+
+var ErrInvalidMnemonic = errors.New("invalid mnemonic")
+
+// NewMnemonic generates a BIP39 english mnemonic with bits of entropy, which should be a
+// multiple of 32 in [128, 256], such as 128 for 12 words and 256 for 24 words.
+func NewMnemonic(bits int) (string, error) {
+	entropy, err := bip39.NewEntropy(bits)
+	if err != nil {
+		return "", err
+	}
+	return bip39.NewMnemonic(entropy)
+}
+
+// ValidateMnemonic checks the words and the checksum of a BIP39 english mnemonic
+func ValidateMnemonic(mnemonic string) error {
+	if _, err := bip39.MnemonicToByteArray(mnemonic); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidMnemonic, err)
+	}
+	return nil
+}
+
+// HDWallet derives the keys of TRON accounts from one seed with BIP32 on TronHDPathFormat, so
+// that the same keys (and addresses) could be recovered from the seed at any time.
+type HDWallet struct {
+	secret    [32]byte
+	chainCode [32]byte
+}
+
+// NewHDWallet imports a BIP39 mnemonic, passphrase is the optional "25th word"
+func NewHDWallet(mnemonic, passphrase string) (*HDWallet, error) {
+	if err := ValidateMnemonic(mnemonic); err != nil {
+		return nil, err
+	}
+	return NewHDWalletFromSeed(bip39.NewSeed(mnemonic, passphrase))
+}
+
+// NewHDWalletFromSeed creates the wallet with a BIP32 seed of 16 to 64 bytes
+func NewHDWalletFromSeed(seed []byte) (*HDWallet, error) {
+	if len(seed) < 16 || len(seed) > 64 {
+		return nil, errors.New("seed should be 16 to 64 bytes")
+	}
+	secret, chainCode := hd.ComputeMastersFromSeed(seed, []byte("Bitcoin seed"))
+	return &HDWallet{secret: secret, chainCode: chainCode}, nil
+}
+
+// Path returns the derivation path of the key
+func (w *HDWallet) Path(account, index uint32) string {
+	return fmt.Sprintf("m/"+TronHDPathFormat, account, index)
+}
+
+// PrivateKey derives the key at m/44'/195'/account'/0/index
+func (w *HDWallet) PrivateKey(account, index uint32) (*ecdsa.PrivateKey, error) {
+	if account > maxHDIndex || index > maxHDIndex {
+		return nil, fmt.Errorf("account and index should not be greater than %d", maxHDIndex)
+	}
+	priv, err := hd.DerivePrivateKeyForPath(secp256k1.S256(), w.secret, w.chainCode,
+		fmt.Sprintf(TronHDPathFormat, account, index))
+	if err != nil {
+		return nil, err
+	}
+	return BytesToPrivateKey(priv[:])
+}
+
+// Signer returns the signer of the key at m/44'/195'/account'/0/index
+func (w *HDWallet) Signer(account, index uint32) (*PrivateKeySigner, error) {
+	key, err := w.PrivateKey(account, index)
+	if err != nil {
+		return nil, err
+	}
+	return newPrivateKeySigner(key), nil
+}
+
+// Address returns the address of the key at m/44'/195'/account'/0/index
+func (w *HDWallet) Address(account, index uint32) (address.Address, error) {
+	key, err := w.PrivateKey(account, index)
+	if err != nil {
+		return nil, err
+	}
+	return address.PubkeyToAddress(key.PublicKey), nil
+}
+
+// Addresses derives count addresses of the account from index start, such as the deposit
+// addresses of users, the i-th of which is always the address of start+i.
+func (w *HDWallet) Addresses(account, start, count uint32) ([]address.Address, error) {
+	if uint64(start)+uint64(count) > maxHDIndex+1 {
+		return nil, fmt.Errorf("index should not be greater than %d", maxHDIndex)
+	}
+	ret := make([]address.Address, 0, count)
+	for i := uint32(0); i < count; i++ {
+		addr, err := w.Address(account, start+i)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, addr)
+	}
+	return ret, nil
+}
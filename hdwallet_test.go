@@ -0,0 +1,70 @@
+package go_tronsdk
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestHDWallet(t *testing.T) {
+	mnemonic := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+	w, err := NewHDWallet(mnemonic, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := w.PrivateKey(0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if h := hex.EncodeToString(crypto.FromECDSA(key)); h != "b5a4cea271ff424d7c31dc12a3e43e401df7a40d7412a15750f3f0b6b5449a28" {
+		t.Fatalf("unexpected key of %s: %s", w.Path(0, 0), h)
+	}
+	signer, err := w.Signer(0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	addrs, err := w.Addresses(0, 0, 3)
+	if err != nil || len(addrs) != 3 {
+		t.Fatalf("expecting 3 addresses, got %v %v", addrs, err)
+	}
+	if !bytes.Equal(addrs[0], signer.Address()) || bytes.Equal(addrs[1], addrs[2]) {
+		t.Fatalf("unexpected addresses %v", addrs)
+	}
+	again, _ := NewHDWallet(mnemonic, "")
+	if addr, err := again.Address(0, 2); err != nil || !bytes.Equal(addr, addrs[2]) {
+		t.Fatalf("address not deterministic: %s %v", addr, err)
+	}
+	if addr, _ := w.Address(1, 0); bytes.Equal(addr, addrs[0]) {
+		t.Fatal("accounts should have different keys")
+	}
+	if withPass, _ := NewHDWallet(mnemonic, "TREZOR"); withPass == nil {
+		t.Fatal("expecting wallet with passphrase")
+	} else if addr, _ := withPass.Address(0, 0); bytes.Equal(addr, addrs[0]) {
+		t.Fatal("passphrase should change the keys")
+	}
+	if _, err = w.Address(0, 1<<31); err == nil {
+		t.Fatal("expecting invalid index")
+	}
+	if _, err = NewHDWallet(strings.Replace(mnemonic, "about", "abandon", 1), ""); !errors.Is(err, ErrInvalidMnemonic) {
+		t.Fatalf("expecting invalid checksum, got %v", err)
+	}
+}
+
+func TestNewMnemonic(t *testing.T) {
+	for bits, words := range map[int]int{128: 12, 256: 24} {
+		m, err := NewMnemonic(bits)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n := len(strings.Fields(m)); n != words || ValidateMnemonic(m) != nil {
+			t.Fatalf("%d bits: unexpected mnemonic of %d words", bits, n)
+		}
+	}
+	if _, err := NewMnemonic(100); err == nil {
+		t.Fatal("expecting invalid entropy size")
+	}
+}
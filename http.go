@@ -3,12 +3,21 @@ package go_tronsdk
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 	"sync"
 	"time"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"google.golang.org/protobuf/proto"
 )
 
 type HttpClient struct {
@@ -17,14 +26,71 @@ type HttpClient struct {
 	lock        sync.Mutex
 	postHeaders http.Header
 	getHeaders  http.Header
+	headers     HeaderProvider
 	timeout     time.Duration
+	visible     bool
+	walletPath  string
+	retry       *RetryPolicy
+	limiter     *RateLimiter
+}
+
+type HttpOption func(*HttpClient)
+
+// WithHttpHeaderProvider sets the provider of authentication headers for each request
+func WithHttpHeaderProvider(p HeaderProvider) HttpOption {
+	return func(c *HttpClient) {
+		c.headers = p
+	}
+}
+
+// WithHttpAPIKey sets TRON-PRO-API-KEY header for each request
+func WithHttpAPIKey(key string) HttpOption {
+	return WithHttpHeaderProvider(APIKey(key))
+}
+
+// WithHttpVisible sends and receives addresses in base58 and names in UTF-8 (visible=true of the
+// HTTP API), rather than hex. Methods still accept and return the same protobuf types.
+func WithHttpVisible(visible bool) HttpOption {
+	return func(c *HttpClient) {
+		c.visible = visible
+	}
+}
+
+// WithHttpSolidity sends requests to the /walletsolidity/* API of a solidity node, which only
+// answers with solidified data. Only the methods of ReadBackend are available.
+func WithHttpSolidity() HttpOption {
+	return func(c *HttpClient) {
+		c.walletPath = "/walletsolidity"
+	}
+}
+
+// WithHttpRetry retries failed requests with p, the method name of a request is the last
+// segment of its path, such as "getaccount"
+func WithHttpRetry(p *RetryPolicy) HttpOption {
+	return func(c *HttpClient) {
+		c.retry = p
+	}
+}
+
+// WithHttpRateLimiter limits the requests with l, basePath is the endpoint of the budgets
+func WithHttpRateLimiter(l *RateLimiter) HttpOption {
+	return func(c *HttpClient) {
+		c.limiter = l
+	}
+}
+
+// WithHttpTLS uses cfg for https connections, such as with a custom CA or client certificates
+func WithHttpTLS(cfg *tls.Config) HttpOption {
+	return func(c *HttpClient) {
+		c.client = newHttpTransportClient(cfg)
+	}
 }
 
 const (
 	JsonContentType = "application/json"
 )
 
-func NewHttpClient(basePath string, timeoutSeconds int64) *HttpClient {
+func NewHttpClient(basePath string, timeoutSeconds int64, opts ...HttpOption) *HttpClient {
 	client := &HttpClient{}
 	client.getHeaders = make(http.Header)
 	client.getHeaders.Set("accept", JsonContentType)
@@ -33,41 +99,468 @@ func NewHttpClient(basePath string, timeoutSeconds int64) *HttpClient {
 	client.postHeaders.Set("content-type", JsonContentType)
 	client.client = new(http.Client)
 	client.basePath = basePath
+	client.walletPath = "/wallet"
 	if timeoutSeconds > 0 {
 		client.timeout = time.Duration(timeoutSeconds) * time.Second
 	} else {
 		client.timeout = 10 * time.Second
 	}
+	for _, opt := range opts {
+		opt(client)
+	}
 	return client
 }
 
 func (c *HttpClient) Close() {}
 
 func (c *HttpClient) GetNextMaintenanceTime(cctx context.Context) (time.Time, error) {
+	data, err := c.fetch(cctx, c.walletPath+"/getnextmaintenancetime", false, nil)
+	if err != nil {
+		return time.Time{}, err
+	}
+	body := &struct {
+		Num int64 `json:"num"`
+	}{}
+	if err = json.Unmarshal(data, body); err != nil {
+		return time.Time{}, err
+	}
+	return maintenanceTime(body.Num), nil
+}
+
+// call posts req (GET if req is nil) to the wallet API at path, and decodes the response into resp
+func (c *HttpClient) call(cctx context.Context, path string, req proto.Message, resp proto.Message) error {
+	data, err := c.callRaw(cctx, path, req, nil)
+	if err != nil {
+		return err
+	}
+	return unmarshalTronJSON(data, resp, c.visible)
+}
+
+// callRaw posts req with extra fields, and returns the response body
+func (c *HttpClient) callRaw(cctx context.Context, path string, req proto.Message, extra map[string]interface{}) ([]byte, error) {
+	var msg interface{}
+	if req != nil {
+		obj, err := marshalTronJSONObject(req, c.visible)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range extra {
+			obj[k] = v
+		}
+		msg = obj
+	}
+	return c.fetch(cctx, path, req != nil, msg)
+}
+
+// fetch requests the wallet API at path with a timeout for each attempt, and returns the response
+// body. Failed requests are retried as the retry policy allows.
+func (c *HttpClient) fetch(cctx context.Context, path string, post bool, msg interface{}) ([]byte, error) {
 	ccctx := cctx
 	if ccctx == nil {
 		ccctx = context.Background()
 	}
-	ctx, cancel := context.WithTimeout(ccctx, c.timeout)
-	defer cancel()
-	resp, err := c.doRequest(ctx, c.basePath+"/wallet/getnextmaintenancetime", false, nil)
+	method := path[strings.LastIndex(path, "/")+1:]
+	return _retryRun(ccctx, c.retry, method, func(rctx context.Context) ([]byte, error) {
+		if err := c.limiter.Wait(rctx, c.basePath, method); err != nil {
+			return nil, err
+		}
+		ctx, cancel := context.WithTimeout(rctx, c.timeout)
+		defer cancel()
+		resp, err := c.doRequest(ctx, c.basePath+path, post, msg)
+		if err != nil {
+			return nil, err
+		}
+		defer func() {
+			_ = resp.Close()
+		}()
+		return io.ReadAll(resp)
+	})
+}
+
+func (c *HttpClient) callBlock(cctx context.Context, path string, req proto.Message) (*api.BlockExtention, error) {
+	data, err := c.callRaw(cctx, path, req, nil)
 	if err != nil {
-		return time.Time{}, err
+		return nil, err
+	}
+	return c.decodeBlock(data)
+}
+
+// decodeBlock converts the block JSON of HTTP API, which is core.Block with blockID, to api.BlockExtention
+func (c *HttpClient) decodeBlock(data []byte) (*api.BlockExtention, error) {
+	blk := new(core.Block)
+	if err := unmarshalTronJSON(data, blk, c.visible); err != nil {
+		return nil, err
+	}
+	var id struct {
+		BlockID string `json:"blockID"`
+	}
+	if err := json.Unmarshal(data, &id); err != nil {
+		return nil, err
+	}
+	ret := &api.BlockExtention{BlockHeader: blk.BlockHeader}
+	if id.BlockID != "" {
+		blockId, err := hex.DecodeString(id.BlockID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid blockID: %w", err)
+		}
+		ret.Blockid = blockId
+	}
+	for _, tx := range blk.Transactions {
+		txId, err := HashMessage(tx.RawData)
+		if err != nil {
+			return nil, err
+		}
+		ret.Transactions = append(ret.Transactions, &api.TransactionExtention{Transaction: tx, Txid: txId})
+	}
+	return ret, nil
+}
+
+func (c *HttpClient) callTxEx(cctx context.Context, path string, req proto.Message) (*api.TransactionExtention, error) {
+	txx := new(api.TransactionExtention)
+	if err := c.call(cctx, path, req, txx); err != nil {
+		return nil, err
+	}
+	if len(txx.Txid) == 0 && txx.Transaction != nil && txx.Transaction.RawData != nil {
+		txId, err := HashMessage(txx.Transaction.RawData)
+		if err != nil {
+			return nil, err
+		}
+		txx.Txid = txId
+	}
+	return txx, nil
+}
+
+func (c *HttpClient) GetNowBlock(ctx context.Context) (*api.BlockExtention, error) {
+	return c.callBlock(ctx, c.walletPath+"/getnowblock", nil)
+}
+
+func (c *HttpClient) GetBlock(ctx context.Context, idOrNum string, detail bool) (*api.BlockExtention, error) {
+	return c.callBlock(ctx, c.walletPath+"/getblock", &api.BlockReq{IdOrNum: idOrNum, Detail: detail})
+}
+
+func (c *HttpClient) GetBlockByLimitNext(ctx context.Context, start, end int64) (*api.BlockListExtention, error) {
+	data, err := c.callRaw(ctx, c.walletPath+"/getblockbylimitnext", &api.BlockLimit{StartNum: start, EndNum: end}, nil)
+	if err != nil {
+		return nil, err
+	}
+	var list struct {
+		Block []json.RawMessage `json:"block"`
+		Error string            `json:"Error"`
+	}
+	if err = json.Unmarshal(data, &list); err != nil {
+		return nil, err
+	}
+	if list.Error != "" {
+		return nil, errors.New(list.Error)
+	}
+	ret := new(api.BlockListExtention)
+	for _, raw := range list.Block {
+		blk, err := c.decodeBlock(raw)
+		if err != nil {
+			return nil, err
+		}
+		ret.Block = append(ret.Block, blk)
+	}
+	return ret, nil
+}
+
+func (c *HttpClient) GetBlockByNum(ctx context.Context, num int64) (*api.BlockExtention, error) {
+	return c.callBlock(ctx, c.walletPath+"/getblockbynum", &api.NumberMessage{Num: num})
+}
+
+func (c *HttpClient) GetTransactionInfoByBlockNum(ctx context.Context, num int64) (*api.TransactionInfoList, error) {
+	data, err := c.callRaw(ctx, c.walletPath+"/gettransactioninfobyblocknum", &api.NumberMessage{Num: num}, nil)
+	if err != nil {
+		return nil, err
+	}
+	v, err := decodeJSONValue(data)
+	if err != nil {
+		return nil, err
+	}
+	ret := new(api.TransactionInfoList)
+	if _, ok := v.([]interface{}); !ok {
+		// an object for error or no transaction
+		if err = unmarshalTronJSON(data, new(core.TransactionInfo), c.visible); err != nil {
+			return nil, err
+		}
+		return ret, nil
+	}
+	var list []json.RawMessage
+	if err = json.Unmarshal(data, &list); err != nil {
+		return nil, err
+	}
+	for _, raw := range list {
+		info := new(core.TransactionInfo)
+		if err = unmarshalTronJSON(raw, info, c.visible); err != nil {
+			return nil, err
+		}
+		ret.TransactionInfo = append(ret.TransactionInfo, info)
+	}
+	return ret, nil
+}
+
+func (c *HttpClient) GetAccount(ctx context.Context, addr address.Address) (*core.Account, error) {
+	acc := new(core.Account)
+	if err := c.call(ctx, c.walletPath+"/getaccount", &core.Account{Address: addr}, acc); err != nil {
+		return nil, err
+	}
+	return acc, nil
+}
+
+func (c *HttpClient) GetAccountResource(ctx context.Context, addr address.Address) (*api.AccountResourceMessage, error) {
+	res := new(api.AccountResourceMessage)
+	if err := c.call(ctx, c.walletPath+"/getaccountresource", &core.Account{Address: addr}, res); err != nil {
+		return nil, err
 	}
-	defer func() {
-		_ = resp.Close()
-	}()
+	return res, nil
+}
+
+func (c *HttpClient) GetTransactionById(ctx context.Context, txId []byte) (*core.Transaction, error) {
+	tx := new(core.Transaction)
+	if err := c.call(ctx, c.walletPath+"/gettransactionbyid", &api.BytesMessage{Value: txId}, tx); err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+func (c *HttpClient) GetTransactionInfoById(ctx context.Context, txId []byte) (*core.TransactionInfo, error) {
+	info := new(core.TransactionInfo)
+	if err := c.call(ctx, c.walletPath+"/gettransactioninfobyid", &api.BytesMessage{Value: txId}, info); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+func (c *HttpClient) TriggerConstantContract(ctx context.Context, tsc *core.TriggerSmartContract) (*api.TransactionExtention, error) {
+	return c.callTxEx(ctx, c.walletPath+"/triggerconstantcontract", tsc)
+}
+
+func (c *HttpClient) TriggerContract(ctx context.Context, tsc *core.TriggerSmartContract) (*api.TransactionExtention, error) {
+	return c.callTxEx(ctx, c.walletPath+"/triggersmartcontract", tsc)
+}
+
+func (c *HttpClient) EstimateEnergy(ctx context.Context, tsc *core.TriggerSmartContract) (*api.EstimateEnergyMessage, error) {
+	ret := new(api.EstimateEnergyMessage)
+	if err := c.call(ctx, c.walletPath+"/estimateenergy", tsc, ret); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// callTx posts tx with its txID and raw_data_hex, and returns the response body
+func (c *HttpClient) callTx(ctx context.Context, path string, tx *core.Transaction) ([]byte, error) {
+	extra := make(map[string]interface{})
+	if tx != nil && tx.RawData != nil {
+		raw, err := proto.Marshal(tx.RawData)
+		if err != nil {
+			return nil, err
+		}
+		txId, _ := HashMessage(tx.RawData)
+		extra["txID"] = hex.EncodeToString(txId)
+		extra["raw_data_hex"] = hex.EncodeToString(raw)
+	}
+	return c.callRaw(ctx, path, tx, extra)
+}
+
+func (c *HttpClient) BroadcastTransaction(ctx context.Context, tx *core.Transaction) (*api.Return, error) {
+	data, err := c.callTx(ctx, c.walletPath+"/broadcasttransaction", tx)
+	if err != nil {
+		return nil, err
+	}
+	ret := new(api.Return)
+	if err = unmarshalTronJSON(data, ret, c.visible); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// BroadcastHex broadcasts the protobuf serialized transaction in hex, which avoids the JSON conversion
+// of the transaction.
+func (c *HttpClient) BroadcastHex(ctx context.Context, tx *core.Transaction) (*api.Return, error) {
+	bs, err := proto.Marshal(tx)
+	if err != nil {
+		return nil, err
+	}
+	data, err := c.fetch(ctx, c.walletPath+"/broadcasthex", true,
+		map[string]string{"transaction": hex.EncodeToString(bs)})
+	if err != nil {
+		return nil, err
+	}
+	// message of broadcasthex is in UTF-8 rather than hex
 	body := &struct {
-		Num int64 `json:"num"`
+		Result  bool   `json:"result"`
+		Code    string `json:"code"`
+		Message string `json:"message"`
+		Error   string `json:"Error"`
 	}{}
-	if err = json.NewDecoder(resp).Decode(body); err != nil {
-		return time.Time{}, err
+	if err = json.Unmarshal(data, body); err != nil {
+		return nil, err
 	}
-	if body.Num > 9999999999 {
-		return time.UnixMilli(body.Num), nil
-	} else {
-		return time.Unix(body.Num, 0), nil
+	if body.Error != "" {
+		return nil, errors.New(body.Error)
 	}
+	ret := &api.Return{Result: body.Result, Message: []byte(body.Message)}
+	if body.Code != "" {
+		code, ok := api.ReturnResponseCode_value[body.Code]
+		if !ok {
+			code = int32(api.Return_OTHER_ERROR)
+		}
+		ret.Code = api.ReturnResponseCode(code)
+	}
+	return ret, nil
+}
+
+func (c *HttpClient) GetTransactionSignWeight(ctx context.Context, tx *core.Transaction) (*api.TransactionSignWeight, error) {
+	data, err := c.callTx(ctx, c.walletPath+"/getsignweight", tx)
+	if err != nil {
+		return nil, err
+	}
+	ret := new(api.TransactionSignWeight)
+	if err = unmarshalTronJSON(data, ret, c.visible); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+func (c *HttpClient) GetTransactionApprovedList(ctx context.Context, tx *core.Transaction) (*api.TransactionApprovedList, error) {
+	data, err := c.callTx(ctx, c.walletPath+"/getapprovedlist", tx)
+	if err != nil {
+		return nil, err
+	}
+	ret := new(api.TransactionApprovedList)
+	if err = unmarshalTronJSON(data, ret, c.visible); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// GetAssetIssueById gets the TRC10 token, the id is sent as is rather than hex
+func (c *HttpClient) GetAssetIssueById(ctx context.Context, id string) (*core.AssetIssueContract, error) {
+	req := map[string]interface{}{"value": id}
+	if c.visible {
+		req["visible"] = true
+	}
+	data, err := c.fetch(ctx, c.walletPath+"/getassetissuebyid", true, req)
+	if err != nil {
+		return nil, err
+	}
+	asset := new(core.AssetIssueContract)
+	if err = unmarshalTronJSON(data, asset, c.visible); err != nil {
+		return nil, err
+	}
+	return asset, nil
+}
+
+func (c *HttpClient) GetDelegatedResourceV2(ctx context.Context, from, to address.Address) (*api.DelegatedResourceList, error) {
+	list := new(api.DelegatedResourceList)
+	req := &api.DelegatedResourceMessage{FromAddress: from, ToAddress: to}
+	if err := c.call(ctx, c.walletPath+"/getdelegatedresourcev2", req, list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func (c *HttpClient) GetDelegatedResourceAccountIndexV2(ctx context.Context, addr address.Address) (*core.DelegatedResourceAccountIndex, error) {
+	req := map[string]interface{}{"value": hex.EncodeToString(addr)}
+	if c.visible {
+		req["value"] = addr.String()
+		req["visible"] = true
+	}
+	data, err := c.fetch(ctx, c.walletPath+"/getdelegatedresourceaccountindexv2", true, req)
+	if err != nil {
+		return nil, err
+	}
+	index := new(core.DelegatedResourceAccountIndex)
+	if err = unmarshalTronJSON(data, index, c.visible); err != nil {
+		return nil, err
+	}
+	return index, nil
+}
+
+func (c *HttpClient) GetCanDelegatedMaxSize(ctx context.Context, owner address.Address, resource core.ResourceCode) (*api.CanDelegatedMaxSizeResponseMessage, error) {
+	ret := new(api.CanDelegatedMaxSizeResponseMessage)
+	req := &api.CanDelegatedMaxSizeRequestMessage{Type: int32(resource), OwnerAddress: owner}
+	if err := c.call(ctx, c.walletPath+"/getcandelegatedmaxsize", req, ret); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// addressRequest is the request of the APIs taking {"address": ...} rather than a proto message
+func (c *HttpClient) addressRequest(addr address.Address) map[string]interface{} {
+	if c.visible {
+		return map[string]interface{}{"address": addr.String(), "visible": true}
+	}
+	return map[string]interface{}{"address": hex.EncodeToString(addr)}
+}
+
+func (c *HttpClient) GetRewardInfo(ctx context.Context, addr address.Address) (int64, error) {
+	data, err := c.fetch(ctx, c.walletPath+"/getReward", true, c.addressRequest(addr))
+	if err != nil {
+		return 0, err
+	}
+	var body struct {
+		Reward int64  `json:"reward"`
+		Error  string `json:"Error"`
+	}
+	if err = json.Unmarshal(data, &body); err != nil {
+		return 0, err
+	}
+	if body.Error != "" {
+		return 0, errors.New(body.Error)
+	}
+	return body.Reward, nil
+}
+
+func (c *HttpClient) GetBrokerageInfo(ctx context.Context, witness address.Address) (int64, error) {
+	data, err := c.fetch(ctx, c.walletPath+"/getBrokerage", true, c.addressRequest(witness))
+	if err != nil {
+		return 0, err
+	}
+	var body struct {
+		Brokerage int64  `json:"brokerage"`
+		Error     string `json:"Error"`
+	}
+	if err = json.Unmarshal(data, &body); err != nil {
+		return 0, err
+	}
+	if body.Error != "" {
+		return 0, errors.New(body.Error)
+	}
+	return body.Brokerage, nil
+}
+
+func (c *HttpClient) GetChainParameters(ctx context.Context) (*core.ChainParameters, error) {
+	params := new(core.ChainParameters)
+	if err := c.call(ctx, c.walletPath+"/getchainparameters", nil, params); err != nil {
+		return nil, err
+	}
+	return params, nil
+}
+
+func (c *HttpClient) GetContract(ctx context.Context, addr address.Address) (*core.SmartContract, error) {
+	var extra map[string]interface{}
+	if c.visible {
+		// the contract address is base58 in visible mode, though value of BytesMessage is hex
+		extra = map[string]interface{}{"value": addr.String()}
+	}
+	data, err := c.callRaw(ctx, c.walletPath+"/getcontract", &api.BytesMessage{Value: addr}, extra)
+	if err != nil {
+		return nil, err
+	}
+	sc := new(core.SmartContract)
+	if err = unmarshalTronJSON(data, sc, c.visible); err != nil {
+		return nil, err
+	}
+	return sc, nil
+}
+
+func (c *HttpClient) ListWitnesses(ctx context.Context) (*api.WitnessList, error) {
+	list := new(api.WitnessList)
+	if err := c.call(ctx, c.walletPath+"/listwitnesses", nil, list); err != nil {
+		return nil, err
+	}
+	return list, nil
 }
 
 func (c *HttpClient) doRequest(ctx context.Context, url string, post bool, msg interface{}) (io.ReadCloser, error) {
@@ -88,7 +581,6 @@ func (c *HttpClient) doRequest(ctx context.Context, url string, post bool, msg i
 		c.lock.Lock()
 		req.Header = c.postHeaders.Clone()
 		c.lock.Unlock()
-		setHeaders(req.Header, headersFromContext(ctx))
 	} else {
 		req, err = http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 		if err != nil {
@@ -98,8 +590,15 @@ func (c *HttpClient) doRequest(ctx context.Context, url string, post bool, msg i
 		c.lock.Lock()
 		req.Header = c.getHeaders.Clone()
 		c.lock.Unlock()
-		setHeaders(req.Header, headersFromContext(ctx))
 	}
+	if c.headers != nil {
+		h, err := c.headers.Headers(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("auth headers: %w", err)
+		}
+		setHeaders(req.Header, h)
+	}
+	setHeaders(req.Header, headersFromContext(ctx))
 
 	// do request
 	resp, err := c.client.Do(req)
@@ -116,6 +615,7 @@ func (c *HttpClient) doRequest(ctx context.Context, url string, post bool, msg i
 		return nil, HTTPError{
 			Status:     resp.Status,
 			StatusCode: resp.StatusCode,
+			Header:     resp.Header,
 			Body:       body,
 		}
 	}
@@ -125,6 +625,7 @@ func (c *HttpClient) doRequest(ctx context.Context, url string, post bool, msg i
 type HTTPError struct {
 	StatusCode int
 	Status     string
+	Header     http.Header
 	Body       []byte
 }
 
@@ -1,9 +1,19 @@
 package go_tronsdk
 
 import (
+	"bytes"
 	"context"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
 	"testing"
 	"time"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
 )
 
 const contentType = "application/json"
@@ -53,3 +63,180 @@ func TestHttpClient_GetNextMaintenanceTime(t *testing.T) {
 	}
 	t.Logf("next time: %s, %s", ti.String(), ti.In(time.UTC))
 }
+
+func TestHttpClient_HeaderProvider(t *testing.T) {
+	var got []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, r.Header.Get(APIKeyHeader)+"|"+r.Header.Get("X-Trace"))
+		_, _ = w.Write([]byte(`{"num":1703318400000}`))
+	}))
+	defer server.Close()
+
+	client := NewHttpClient(server.URL, 5, WithHttpHeaderProvider(RotatingAPIKeys("k1", "k2")))
+	defer client.Close()
+	ctx := ContextWithHeaders(context.Background(), http.Header{"X-Trace": []string{"t"}})
+	for i := 0; i < 3; i++ {
+		if _, err := client.GetNextMaintenanceTime(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if want := []string{"k1|t", "k2|t", "k1|t"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("headers: %v, want %v", got, want)
+	}
+}
+
+type traceKey struct{}
+
+func TestNewClient_JSONRPCHeaderProvider(t *testing.T) {
+	var got []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, r.Header.Get("X-Trace"))
+		var req struct {
+			ID json.RawMessage `json:"id"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":` + string(req.ID) + `,"result":"0x2b6653dc"}`))
+	}))
+	defer server.Close()
+
+	provider := HeaderProviderFunc(func(ctx context.Context) (http.Header, error) {
+		trace, _ := ctx.Value(traceKey{}).(string)
+		return http.Header{"X-Trace": []string{trace}}, nil
+	})
+	ctx := context.WithValue(context.Background(), traceKey{}, "init")
+	client, err := NewClient(ctx, WithJSONRPC(server.URL), WithHeaderProvider(provider))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = client.Close()
+	}()
+	if _, err = client.eth.ChainID(context.WithValue(context.Background(), traceKey{}, "call")); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"init", "call"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("headers: %v, want %v", got, want)
+	}
+}
+
+const (
+	testOwnerHex  = "41a614f803b6fd780986a42c78ec9c7f77e6ded13c"
+	testToHex     = "4178c842ee63b253f8f0d2955bbc582c661a078c9d"
+	testBlockJSON = `{"blockID":"0000000002b7b0c5a9b3c7e1f1d2f0d0e1c3b5a79f6e4d3c2b1a09f8e7d6c5b4","block_header":{"raw_data":{"number":45592773,"txTrieRoot":"00","witness_address":"` + testOwnerHex + `","parentHash":"0000000002b7b0c4","version":28,"timestamp":1700000000000},"witness_signature":"ab"},"transactions":[{"ret":[{"contractRet":"SUCCESS"}],"signature":["aa"],"txID":"ff","raw_data":{"contract":[{"parameter":{"value":{"amount":1000,"owner_address":"` + testOwnerHex + `","to_address":"` + testToHex + `"},"type_url":"type.googleapis.com/protocol.TransferContract"},"type":"TransferContract"}],"ref_block_bytes":"b0b3","ref_block_hash":"0102030405060708","expiration":1700000060000,"timestamp":1700000000000},"raw_data_hex":"00"}]}`
+)
+
+func TestHttpClient_Backend(t *testing.T) {
+	var broadcast map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/wallet/getnowblock":
+			_, _ = w.Write([]byte(testBlockJSON))
+		case "/wallet/getaccount":
+			_, _ = w.Write([]byte(`{"address":"` + testOwnerHex + `","balance":9007199254740993,"account_name":"6162","assetV2":[{"key":"1002000","value":5}],"owner_permission":{"permission_name":"owner","threshold":1,"keys":[{"address":"` + testOwnerHex + `","weight":1}]}}`))
+		case "/wallet/broadcasttransaction":
+			_ = json.NewDecoder(r.Body).Decode(&broadcast)
+			_, _ = w.Write([]byte(`{"code":"SIGERROR","message":"` + hex.EncodeToString([]byte("validate signature error")) + `"}`))
+		case "/wallet/getcontract":
+			_, _ = w.Write([]byte(`{"Error":"class org.tron.core.exception.BadItemException : invalid address"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	var b Backend = NewHttpClient(server.URL, 5)
+	blk, err := b.GetNowBlock(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if blk.BlockHeader.RawData.Number != 45592773 || len(blk.Blockid) != 32 || len(blk.Transactions) != 1 {
+		t.Fatalf("unexpected block: %v", blk)
+	}
+	tx := blk.Transactions[0].Transaction
+	transfer := new(core.TransferContract)
+	if err = tx.RawData.Contract[0].Parameter.UnmarshalTo(transfer); err != nil {
+		t.Fatal(err)
+	}
+	if transfer.Amount != 1000 || hex.EncodeToString(transfer.ToAddress) != testToHex {
+		t.Fatalf("unexpected transfer: %v", transfer)
+	}
+	if txId, _ := HashMessage(tx.RawData); !bytes.Equal(txId, blk.Transactions[0].Txid) {
+		t.Fatalf("txid mismatch")
+	}
+
+	acc, err := b.GetAccount(context.Background(), address.HexToAddress(testOwnerHex))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if acc.Balance != 9007199254740993 || string(acc.AccountName) != "ab" || acc.AssetV2["1002000"] != 5 ||
+		acc.OwnerPermission.Keys[0].Weight != 1 {
+		t.Fatalf("unexpected account: %v", acc)
+	}
+
+	ret, err := b.BroadcastTransaction(context.Background(), tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ret.Code != api.Return_SIGERROR || string(ret.Message) != "validate signature error" {
+		t.Fatalf("unexpected return: %v", ret)
+	}
+	raw, _ := broadcast["raw_data"].(map[string]interface{})
+	contract := raw["contract"].([]interface{})[0].(map[string]interface{})
+	param := contract["parameter"].(map[string]interface{})
+	if param["type_url"] != "type.googleapis.com/protocol.TransferContract" ||
+		param["value"].(map[string]interface{})["owner_address"] != testOwnerHex || broadcast["txID"] == nil {
+		t.Fatalf("unexpected broadcast request: %v", broadcast)
+	}
+
+	if _, err = b.GetContract(context.Background(), nil); err == nil {
+		t.Fatal("expecting error")
+	}
+}
+
+func TestHttpClient_Visible(t *testing.T) {
+	owner := address.HexToAddress(testOwnerHex)
+	var req map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/wallet/getaccount":
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			_, _ = w.Write([]byte(`{"address":"` + owner.String() + `","account_name":"treasury","balance":1}`))
+		case "/wallet/gettransactioninfobyblocknum":
+			_, _ = w.Write([]byte(`[{"id":"01","blockNumber":7,"contract_address":"` + owner.String() + `","receipt":{"result":"SUCCESS"}},{"id":"02","blockNumber":7}]`))
+		case "/wallet/broadcasthex":
+			_, _ = w.Write([]byte(`{"result":false,"code":"DUP_TRANSACTION_ERROR","message":"dup transaction"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewHttpClient(server.URL, 5, WithHttpVisible(true))
+	acc, err := client.GetAccount(context.Background(), owner)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if req["address"] != owner.String() || req["visible"] != true {
+		t.Fatalf("unexpected request: %v", req)
+	}
+	if !bytes.Equal(acc.Address, owner) || string(acc.AccountName) != "treasury" {
+		t.Fatalf("unexpected account: %v", acc)
+	}
+
+	infos, err := client.GetTransactionInfoByBlockNum(context.Background(), 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(infos.TransactionInfo) != 2 || !bytes.Equal(infos.TransactionInfo[0].ContractAddress, owner) ||
+		infos.TransactionInfo[0].Receipt.Result != core.Transaction_Result_SUCCESS {
+		t.Fatalf("unexpected infos: %v", infos)
+	}
+
+	ret, err := client.BroadcastHex(context.Background(), &core.Transaction{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ret.Code != api.Return_DUP_TRANSACTION_ERROR || string(ret.Message) != "dup transaction" {
+		t.Fatalf("unexpected return: %v", ret)
+	}
+}
@@ -0,0 +1,107 @@
+package go_tronsdk
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+)
+
+// keyFileJSON is the version 3 Web3 Secret Storage format, the address is in the 20 bytes form
+// without the TRON prefix, so that the files could also be used by go-ethereum keystore.
+type keyFileJSON struct {
+	Address string              `json:"address"`
+	Crypto  keystore.CryptoJSON `json:"crypto"`
+	Id      string              `json:"id"`
+	Version int                 `json:"version"`
+}
+
+func newKeyFileID() (string, error) {
+	var u [16]byte
+	if _, err := rand.Read(u[:]); err != nil {
+		return "", err
+	}
+	u[6] = (u[6] & 0x0f) | 0x40 // version 4
+	u[8] = (u[8] & 0x3f) | 0x80 // variant 10
+	return fmt.Sprintf("%x-%x-%x-%x-%x", u[0:4], u[4:6], u[6:8], u[8:10], u[10:]), nil
+}
+
+// EncryptKeyJSON encrypts key with passphrase into a Web3 Secret Storage JSON with scrypt, use
+// keystore.StandardScryptN and keystore.StandardScryptP for scryptN and scryptP unless the
+// passphrase is strong enough for lighter parameters.
+func EncryptKeyJSON(key *ecdsa.PrivateKey, passphrase string, scryptN, scryptP int) ([]byte, error) {
+	if key == nil {
+		return nil, errors.New("nil key")
+	}
+	id, err := newKeyFileID()
+	if err != nil {
+		return nil, err
+	}
+	cj, err := keystore.EncryptDataV3(math.PaddedBigBytes(key.D, 32), []byte(passphrase), scryptN, scryptP)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(&keyFileJSON{
+		Address: hex.EncodeToString(crypto.PubkeyToAddress(key.PublicKey).Bytes()),
+		Crypto:  cj,
+		Id:      id,
+		Version: 3,
+	})
+}
+
+// DecryptKeyJSON decrypts a Web3 Secret Storage JSON (scrypt or pbkdf2) with passphrase
+func DecryptKeyJSON(keyJSON []byte, passphrase string) (*PrivateKeySigner, error) {
+	k, err := keystore.DecryptKey(keyJSON, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	return newPrivateKeySigner(k.PrivateKey), nil
+}
+
+// SaveKeyFile writes key encrypted by EncryptKeyJSON into a new file of dir, and returns the
+// path of the file, which is named by the time and the TRON address.
+func SaveKeyFile(dir string, key *ecdsa.PrivateKey, passphrase string, scryptN, scryptP int) (string, error) {
+	keyJSON, err := EncryptKeyJSON(key, passphrase, scryptN, scryptP)
+	if err != nil {
+		return "", err
+	}
+	if err = os.MkdirAll(dir, 0700); err != nil {
+		return "", err
+	}
+	name := fmt.Sprintf("UTC--%s--%s", time.Now().UTC().Format("2006-01-02T15-04-05.000000000Z"),
+		address.PubkeyToAddress(key.PublicKey))
+	path := filepath.Join(dir, name)
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return "", err
+	}
+	if _, err = f.Write(keyJSON); err != nil {
+		_ = f.Close()
+		_ = os.Remove(path)
+		return "", err
+	}
+	if err = f.Close(); err != nil {
+		_ = os.Remove(path)
+		return "", err
+	}
+	return path, nil
+}
+
+// LoadKeyFile reads and decrypts a Web3 Secret Storage file with passphrase
+func LoadKeyFile(path, passphrase string) (*PrivateKeySigner, error) {
+	keyJSON, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return DecryptKeyJSON(keyJSON, passphrase)
+}
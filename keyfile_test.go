@@ -0,0 +1,56 @@
+package go_tronsdk
+
+import (
+	"bytes"
+	"encoding/hex"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+)
+
+func TestKeyFile(t *testing.T) {
+	priv, _ := hex.DecodeString("4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318")
+	key, _ := BytesToPrivateKey(priv)
+	signer, _ := NewPrivateKeySigner(priv)
+	dir := t.TempDir()
+	path, err := SaveKeyFile(dir, key, "secret", keystore.LightScryptN, keystore.LightScryptP)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if filepath.Dir(path) != dir || !strings.HasSuffix(path, signer.Address().String()) {
+		t.Fatalf("unexpected key file %s", path)
+	}
+	loaded, err := LoadKeyFile(path, "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(loaded.Address(), signer.Address()) {
+		t.Fatalf("expecting %s, got %s", signer.Address(), loaded.Address())
+	}
+	if _, err = LoadKeyFile(path, "wrong"); err == nil {
+		t.Fatal("expecting wrong passphrase")
+	}
+	// go-ethereum keystore should recognize the file
+	ks := keystore.NewKeyStore(dir, keystore.LightScryptN, keystore.LightScryptP)
+	if accounts := ks.Accounts(); len(accounts) != 1 || !bytes.Equal(accounts[0].Address.Bytes(), signer.Address()[1:]) {
+		t.Fatalf("unexpected keystore accounts %v", accounts)
+	}
+}
+
+func TestDecryptKeyJSON_PBKDF2(t *testing.T) {
+	keyJSON := `{"crypto":{"cipher":"aes-128-ctr","cipherparams":{"iv":"6087dab2f9fdbbfaddc31a909735c1e6"},
+"ciphertext":"5318b4d5bcd28de64ee5559e671353e16f075ecae9f99c7a79a38af5f869aa46","kdf":"pbkdf2",
+"kdfparams":{"c":262144,"dklen":32,"prf":"hmac-sha256","salt":"ae3cd4e7013836a3df6bd7241b12db061dbe2c6785853cce422d148a624ce0bd"},
+"mac":"517ead924a9d0dc3124507e3393d175ce3ff7c1e96529c6c555ce9e51205e9b2"},"id":"3198bc9c-6672-5ab3-d995-4942343ae5b6","version":3}`
+	signer, err := DecryptKeyJSON([]byte(keyJSON), "testpassword")
+	if err != nil {
+		t.Fatal(err)
+	}
+	priv, _ := hex.DecodeString("7a28b5ba57c53603b0b07b56bba752f7784bf506fa95edc395f5cf6c7514fe9d")
+	expected, _ := NewPrivateKeySigner(priv)
+	if !bytes.Equal(signer.Address(), expected.Address()) {
+		t.Fatalf("expecting %s, got %s", expected.Address(), signer.Address())
+	}
+}
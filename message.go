@@ -0,0 +1,119 @@
+package go_tronsdk
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+)
+
+// TronMessagePrefix is the prefix of signed messages (TIP-191), so that the signature could not
+// be used as a transaction signature
+const TronMessagePrefix = "\x19TRON Signed Message:\n"
+
+// MessageVersion is the way of hashing signed messages, the same as TronWeb
+type MessageVersion int
+
+const (
+	// MessageV1 is trx.signMessage/verifyMessage of TronWeb, for messages of 32 bytes (such as
+	// hashes), the length in the prefix is always 32.
+	MessageV1 MessageVersion = 1
+	// MessageV2 is trx.signMessageV2/verifyMessageV2 of TronWeb, for messages of any length
+	MessageV2 MessageVersion = 2
+)
+
+func (v MessageVersion) String() string {
+	switch v {
+	case MessageV1:
+		return "V1"
+	case MessageV2:
+		return "V2"
+	default:
+		return "MessageVersion-" + strconv.Itoa(int(v))
+	}
+}
+
+// SignedMessageHash returns the keccak256 hash signed for msg:
+//
+//	V1: keccak256(TronMessagePrefix || "32" || msg)
+//	V2: keccak256(TronMessagePrefix || len(msg) in decimal || msg)
+func SignedMessageHash(msg []byte, version MessageVersion) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString(TronMessagePrefix)
+	switch version {
+	case MessageV1:
+		buf.WriteString("32")
+	case MessageV2:
+		buf.WriteString(strconv.Itoa(len(msg)))
+	default:
+		return nil, fmt.Errorf("unknown message version %d", version)
+	}
+	buf.Write(msg)
+	return crypto.Keccak256(buf.Bytes()), nil
+}
+
+// SignMessage signs msg by signer, and returns the 65 bytes signature [R || S || V] where V is
+// 27 or 28, which is the same as TronWeb (without the "0x" of the hex string). The signer must be
+// able to sign a hash without the transaction, see Signer.SignTxID.
+func SignMessage(ctx context.Context, signer Signer, msg []byte, version MessageVersion) ([]byte, error) {
+	if signer == nil {
+		return nil, errors.New("nil signer")
+	}
+	hash, err := SignedMessageHash(msg, version)
+	if err != nil {
+		return nil, err
+	}
+	return signHash(ctx, signer, hash)
+}
+
+// signHash returns the signature of hash with V of 27 or 28
+func signHash(ctx context.Context, signer Signer, hash []byte) ([]byte, error) {
+	sig, err := signer.SignTxID(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	if len(sig) != crypto.SignatureLength {
+		return nil, fmt.Errorf("invalid signature length %d", len(sig))
+	}
+	sig[crypto.RecoveryIDOffset] += 27
+	return sig, nil
+}
+
+// RecoverAddress returns the address which signed msg, the String() of which is the base58
+// address returned by TronWeb trx.verifyMessageV2. V of sig could be 0, 1, 27 or 28.
+func RecoverAddress(msg, sig []byte, version MessageVersion) (address.Address, error) {
+	hash, err := SignedMessageHash(msg, version)
+	if err != nil {
+		return nil, err
+	}
+	return recoverHashSigner(hash, sig)
+}
+
+func recoverHashSigner(hash, sig []byte) (address.Address, error) {
+	if len(sig) != crypto.SignatureLength {
+		return nil, fmt.Errorf("signature should be %d bytes", crypto.SignatureLength)
+	}
+	rsv := make([]byte, len(sig))
+	copy(rsv, sig)
+	if rsv[crypto.RecoveryIDOffset] >= 27 {
+		rsv[crypto.RecoveryIDOffset] -= 27
+	}
+	pub, err := crypto.SigToPub(hash, rsv)
+	if err != nil {
+		return nil, err
+	}
+	return address.PubkeyToAddress(*pub), nil
+}
+
+// VerifyMessage checks whether msg is signed by addr
+func VerifyMessage(msg, sig []byte, addr address.Address, version MessageVersion) bool {
+	signer, err := RecoverAddress(msg, sig, version)
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(signer, addr)
+}
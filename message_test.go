@@ -0,0 +1,97 @@
+package go_tronsdk
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/fbsobreira/gotron-sdk/pkg/keystore"
+)
+
+func TestSignedMessageHash(t *testing.T) {
+	h, _ := SignedMessageHash([]byte("hello world"), MessageV2)
+	if !bytes.Equal(h, crypto.Keccak256([]byte("\x19TRON Signed Message:\n11hello world"))) {
+		t.Fatalf("unexpected V2 hash %x", h)
+	}
+	txid := sha256.Sum256([]byte("raw data"))
+	h, _ = SignedMessageHash(txid[:], MessageV1)
+	if !bytes.Equal(h, crypto.Keccak256(append([]byte("\x19TRON Signed Message:\n32"), txid[:]...))) {
+		t.Fatalf("unexpected V1 hash %x", h)
+	}
+	if _, err := SignedMessageHash(nil, 3); err == nil {
+		t.Fatal("expecting unknown version")
+	}
+	// the same as TextHash of gotron-sdk, which is used by wallet-cli
+	for _, msg := range [][]byte{[]byte("hello world"), txid[:], nil} {
+		v1, _ := SignedMessageHash(msg, MessageV1)
+		v2, _ := SignedMessageHash(msg, MessageV2)
+		if !bytes.Equal(v1, keystore.TextHash(msg, true)) || !bytes.Equal(v2, keystore.TextHash(msg)) {
+			t.Fatalf("hash of %q differs from gotron-sdk", msg)
+		}
+	}
+}
+
+func TestSignMessage_Vectors(t *testing.T) {
+	priv, _ := hex.DecodeString("4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318")
+	signer, _ := NewPrivateKeySigner(priv)
+	txid := sha256.Sum256([]byte("raw data"))
+	tests := []struct {
+		version MessageVersion
+		msg     []byte
+		sig     string
+	}{
+		{MessageV1, txid[:], "939d1e2c8e87133caeb871cce54fe3b968bce7da4384abe7092423aed5cfa9ce" +
+			"7cad412737341d3bc997e1276376b20f06a179b2ccbbbf25fa72721f42f9a3cd1b"},
+		{MessageV2, []byte("hello world"), "41a5fc8de75f56602253325389c65ae530b1631447c79cab653b9f86de016b30" +
+			"78b9bc0b52cc958fe4a71f673d7c3c187ada67ef4c4ad075f2e214101d2161791b"},
+	}
+	for _, tt := range tests {
+		// the signatures are deterministic (RFC 6979)
+		sig, err := SignMessage(context.Background(), signer, tt.msg, tt.version)
+		if err != nil || hex.EncodeToString(sig) != tt.sig {
+			t.Fatalf("%s: expecting %s, got %x %v", tt.version, tt.sig, sig, err)
+		}
+		expected, _ := hex.DecodeString(tt.sig)
+		addr, err := RecoverAddress(tt.msg, expected, tt.version)
+		if err != nil || addr.String() != "TE2H9hWjzYdwzDFRJfx9BFhr4MmjH1CHaz" {
+			t.Fatalf("%s: unexpected signer %s %v", tt.version, addr, err)
+		}
+	}
+}
+
+func TestSignMessage(t *testing.T) {
+	priv, _ := hex.DecodeString("4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318")
+	signer, _ := NewPrivateKeySigner(priv)
+	other, _ := NewPrivateKeySigner(bytes.Repeat([]byte{1}, 32))
+	msg := []byte("I own this address")
+	for _, version := range []MessageVersion{MessageV1, MessageV2} {
+		sig, err := SignMessage(context.Background(), signer, msg, version)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if v := sig[64]; v != 27 && v != 28 {
+			t.Fatalf("%s: expecting v 27 or 28, got %d", version, v)
+		}
+		addr, err := RecoverAddress(msg, sig, version)
+		if err != nil || addr.String() != signer.Address().String() {
+			t.Fatalf("%s: expecting %s, got %s %v", version, signer.Address(), addr, err)
+		}
+		if !VerifyMessage(msg, sig, signer.Address(), version) || VerifyMessage(msg, sig, other.Address(), version) {
+			t.Fatalf("%s: verify failed", version)
+		}
+		if VerifyMessage([]byte("I own that address"), sig, signer.Address(), version) {
+			t.Fatalf("%s: tampered message verified", version)
+		}
+		sig[64] -= 27
+		if !VerifyMessage(msg, sig, signer.Address(), version) {
+			t.Fatalf("%s: v of 0 or 1 should be accepted", version)
+		}
+	}
+	v1, _ := SignMessage(context.Background(), signer, msg, MessageV1)
+	if VerifyMessage(msg, v1, signer.Address(), MessageV2) {
+		t.Fatal("V1 signature verified as V2")
+	}
+}
@@ -0,0 +1,186 @@
+package go_tronsdk
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"google.golang.org/protobuf/proto"
+)
+
+// MaxTxExpiration is the max expiration of a transaction accepted by the chain, the time left
+// for collecting the signatures of a multisig transaction
+const MaxTxExpiration = 24 * time.Hour
+
+var (
+	ErrInsufficientSignWeight = errors.New("insufficient sign weight")
+	ErrTxExpired              = errors.New("transaction expired")
+)
+
+// A multisig transaction is sent in four steps:
+//  1. BuildTx or BuildTriggerTx builds the unsigned transaction of the multisig account, with
+//     TxOptions.PermissionID and an Expiration long enough for the co-signers
+//  2. MarshalTx serializes it for the co-signers, who get it back by UnmarshalTx
+//  3. each co-signer appends its signature by AddSignature
+//  4. BroadcastMultisig checks the accumulated weight and broadcasts it
+
+// BuildTx builds the unsigned transaction of the builtin contract, whose owner_address is the
+// account the transaction is for, rather than any of the signers.
+func (c *TronClient) BuildTx(ctx context.Context, typ core.Transaction_Contract_ContractType, contract proto.Message,
+	opts *TxOptions) (*api.TransactionExtention, error) {
+	if contract == nil {
+		return nil, errors.New("nil contract")
+	}
+	if opts.expiration() > MaxTxExpiration {
+		return nil, fmt.Errorf("expiration should not be longer than %s", MaxTxExpiration)
+	}
+	tx, err := c.newTx(ctx, typ, contract, opts)
+	if err != nil {
+		return nil, err
+	}
+	txId, err := HashMessage(tx.RawData)
+	if err != nil {
+		return nil, err
+	}
+	return &api.TransactionExtention{Transaction: tx, Txid: txId}, nil
+}
+
+// BuildTriggerTx builds the unsigned transaction of owner calling contract with data, see
+// TriggerContract.
+func (c *TronClient) BuildTriggerTx(ctx context.Context, owner, contract address.Address, value int64, data []byte,
+	opts *TxOptions, token ...TokenValue) (*api.TransactionExtention, error) {
+	if !owner.IsValid() {
+		return nil, errors.New("invalid owner address")
+	}
+	if opts != nil && opts.Expiration > MaxTxExpiration {
+		return nil, fmt.Errorf("expiration should not be longer than %s", MaxTxExpiration)
+	}
+	var tv *TokenValue
+	if len(token) > 0 {
+		tv = &token[0]
+	}
+	txx, err := c.buildTriggerTx(ctx, owner, contract, value, data, tv, opts)
+	if err != nil {
+		return nil, err
+	}
+	if opts != nil && opts.Expiration > 0 {
+		// replaces the default expiration of the fullnode. It is after the head block as newTx,
+		// rather than the creation time, which the chain rejects beyond MaxTxExpiration.
+		blk, err := c.GetNowBlock(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("get head block failed: %w", err)
+		}
+		if blk == nil || blk.BlockHeader == nil || blk.BlockHeader.RawData == nil {
+			return nil, errors.New("invalid head block")
+		}
+		raw := txx.Transaction.RawData
+		raw.Expiration = blk.BlockHeader.RawData.Timestamp + opts.Expiration.Milliseconds()
+		if txx.Txid, err = HashMessage(raw); err != nil {
+			return nil, err
+		}
+	}
+	return txx, nil
+}
+
+// MarshalTx serializes tx with its signatures in protobuf, which could be hex encoded for the
+// co-signers
+func MarshalTx(tx *core.Transaction) ([]byte, error) {
+	if tx == nil || tx.RawData == nil {
+		return nil, ErrInvalidTx
+	}
+	return proto.Marshal(tx)
+}
+
+// UnmarshalTx parses the transaction serialized by MarshalTx
+func UnmarshalTx(data []byte) (*core.Transaction, error) {
+	tx := new(core.Transaction)
+	if err := proto.Unmarshal(data, tx); err != nil {
+		return nil, err
+	}
+	if tx.RawData == nil {
+		return nil, ErrInvalidTx
+	}
+	return tx, nil
+}
+
+// AddSignature appends the signature of signer to tx. The signers already signed are kept, and
+// one signer could not sign twice.
+func AddSignature(ctx context.Context, signer Signer, tx *core.Transaction) error {
+	if signer == nil {
+		return errors.New("nil signer")
+	}
+	if tx == nil || tx.RawData == nil {
+		return ErrInvalidTx
+	}
+	if time.UnixMilli(tx.RawData.Expiration).Before(time.Now()) {
+		return ErrTxExpired
+	}
+	signers, err := (*Tx)(tx).Signers()
+	if err != nil {
+		return err
+	}
+	for _, s := range signers {
+		if bytes.Equal(s, signer.Address()) {
+			return fmt.Errorf("%w: %s", ErrDuplicatedSignature, s)
+		}
+	}
+	txId, err := HashMessage(tx.RawData)
+	if err != nil {
+		return err
+	}
+	sig, err := signTx(ctx, signer, txId, tx.RawData)
+	if err != nil {
+		return fmt.Errorf("sign failed: %w", err)
+	}
+	tx.Signature = append(tx.Signature, sig)
+	return nil
+}
+
+// GetTransactionSignWeight gets the weight of the signatures of tx by the fullnode
+func (c *TronClient) GetTransactionSignWeight(cctx context.Context, tx *core.Transaction) (*api.TransactionSignWeight, error) {
+	return _backendRun(cctx, c, "GetTransactionSignWeight", func(ctx context.Context, b Backend) (*api.TransactionSignWeight, error) {
+		return b.GetTransactionSignWeight(ctx, tx)
+	})
+}
+
+// GetTransactionApprovedList gets the signers of tx by the fullnode
+func (c *TronClient) GetTransactionApprovedList(cctx context.Context, tx *core.Transaction) (*api.TransactionApprovedList, error) {
+	return _backendRun(cctx, c, "GetTransactionApprovedList", func(ctx context.Context, b Backend) (*api.TransactionApprovedList, error) {
+		return b.GetTransactionApprovedList(ctx, tx)
+	})
+}
+
+// SignWeight checks the signatures of tx against the current permissions of its owner locally,
+// see Tx.VerifyAgainstPermission
+func (c *TronClient) SignWeight(ctx context.Context, tx *core.Transaction) (*SignWeight, error) {
+	owner, err := (*Tx)(tx).OwnerAddress()
+	if err != nil {
+		return nil, err
+	}
+	acc, err := c.GetAccount(ctx, owner)
+	if err != nil {
+		return nil, fmt.Errorf("get account %s failed: %w", owner, err)
+	}
+	if acc == nil || len(acc.Address) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrAccountNotActivated, owner)
+	}
+	return (*Tx)(tx).VerifyAgainstPermission(acc)
+}
+
+// BroadcastMultisig broadcasts tx if the weight of its signatures reaches the threshold of the
+// permission, otherwise ErrInsufficientSignWeight is returned with the weight.
+func (c *TronClient) BroadcastMultisig(ctx context.Context, tx *core.Transaction) (*SignWeight, error) {
+	w, err := c.SignWeight(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	if !w.Enough() {
+		return w, fmt.Errorf("%w: %d/%d", ErrInsufficientSignWeight, w.Weight, w.Permission.Threshold)
+	}
+	return w, c.BroadcastTransaction(ctx, tx)
+}
@@ -0,0 +1,168 @@
+package go_tronsdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"google.golang.org/grpc"
+)
+
+type multisigWallet struct {
+	*fakeWallet
+	account *core.Account
+}
+
+func (w *multisigWallet) GetNowBlock2(ctx context.Context, in *api.EmptyMessage, opts ...grpc.CallOption) (*api.BlockExtention, error) {
+	blk, err := w.fakeWallet.GetNowBlock2(ctx, in, opts...)
+	if err == nil {
+		blk.BlockHeader.RawData.Timestamp = time.Now().UnixMilli()
+	}
+	return blk, err
+}
+
+func (w *multisigWallet) GetAccount(_ context.Context, _ *core.Account, _ ...grpc.CallOption) (*core.Account, error) {
+	return w.account, nil
+}
+
+func (w *multisigWallet) GetTransactionApprovedList(_ context.Context, in *core.Transaction, _ ...grpc.CallOption) (*api.TransactionApprovedList, error) {
+	signers, err := (*Tx)(in).Signers()
+	if err != nil {
+		return nil, err
+	}
+	ret := &api.TransactionApprovedList{Transaction: &api.TransactionExtention{Transaction: in}}
+	for _, s := range signers {
+		ret.ApprovedList = append(ret.ApprovedList, s)
+	}
+	return ret, nil
+}
+
+func TestMultisig(t *testing.T) {
+	signers := testSigners(t, 6)
+	treasury := signers[0].Address()
+	active := &core.Permission{Type: core.Permission_Active, Id: 2, Threshold: 3, Operations: make([]byte, 32)}
+	active.Operations[core.Transaction_Contract_TransferContract/8] |= 1 << (core.Transaction_Contract_TransferContract % 8)
+	for _, s := range signers[1:] {
+		active.Keys = append(active.Keys, &core.Key{Address: s.Address(), Weight: 1})
+	}
+	w := &multisigWallet{fakeWallet: &fakeWallet{name: "fake", height: 100},
+		account: &core.Account{Address: treasury, ActivePermission: []*core.Permission{active}}}
+	client, err := NewClient(context.Background(), WithBackend("fake", NewGrpcBackend(w)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = client.Close()
+	}()
+	to := signers[1].Address()
+
+	txx, err := client.BuildTx(context.Background(), core.Transaction_Contract_TransferContract,
+		&core.TransferContract{OwnerAddress: treasury, ToAddress: to, Amount: 1_000_000},
+		&TxOptions{PermissionID: 2, Expiration: time.Hour})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pid := txx.Transaction.RawData.Contract[0].PermissionId; pid != 2 || len(txx.Transaction.Signature) != 0 {
+		t.Fatalf("expecting unsigned transaction of permission 2, got %d", pid)
+	}
+	data, err := MarshalTx(txx.Transaction)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, s := range signers[1:4] {
+		tx, err := UnmarshalTx(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = AddSignature(context.Background(), s, tx); err != nil {
+			t.Fatal(err)
+		}
+		if i == 0 {
+			if err = AddSignature(context.Background(), s, tx); !errors.Is(err, ErrDuplicatedSignature) {
+				t.Fatalf("expecting duplicated signature, got %v", err)
+			}
+			if _, err = client.BroadcastMultisig(context.Background(), tx); !errors.Is(err, ErrInsufficientSignWeight) {
+				t.Fatalf("expecting insufficient weight, got %v", err)
+			}
+		}
+		if data, err = MarshalTx(tx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	tx, _ := UnmarshalTx(data)
+	if txId, _ := (*Tx)(tx).TxID(); !bytes.Equal(txId, txx.Txid) {
+		t.Fatal("txid changed by signing")
+	}
+	approved, err := client.GetTransactionApprovedList(context.Background(), tx)
+	if err != nil || len(approved.ApprovedList) != 3 {
+		t.Fatalf("expecting 3 approved, got %v %v", approved, err)
+	}
+	sw, err := client.BroadcastMultisig(context.Background(), tx)
+	if err != nil || sw.Weight != 3 || len(w.broadcasts) != 1 {
+		t.Fatalf("expecting broadcast with weight 3, got %v %v", sw, err)
+	}
+
+	tx.RawData.Expiration = time.Now().Add(-time.Second).UnixMilli()
+	if err = AddSignature(context.Background(), signers[4], tx); !errors.Is(err, ErrTxExpired) {
+		t.Fatalf("expecting expired, got %v", err)
+	}
+	if _, err = client.BuildTx(context.Background(), core.Transaction_Contract_TransferContract,
+		&core.TransferContract{OwnerAddress: treasury, ToAddress: to, Amount: 1}, &TxOptions{Expiration: 25 * time.Hour}); err == nil {
+		t.Fatal("expecting expiration too long")
+	}
+}
+
+func TestTronClient_BuildTriggerTx(t *testing.T) {
+	w := &energyWallet{fakeWallet: &fakeWallet{name: "fake", height: 100}}
+	client, err := NewClient(context.Background(), WithBackend("fake", NewGrpcBackend(w)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = client.Close()
+	}()
+	owner, _ := hex.DecodeString(testOwnerHex)
+	contract, _ := hex.DecodeString(testToHex)
+
+	// the chain accepts expiration up to 24 hours after the head block
+	txx, err := client.BuildTriggerTx(context.Background(), owner, contract, 0, nil, &TxOptions{Expiration: MaxTxExpiration})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exp := txx.Transaction.RawData.Expiration; exp != 1700000000000+MaxTxExpiration.Milliseconds() {
+		t.Fatalf("expecting expiration 24h after the head block, got %d", exp)
+	}
+	if txId, _ := HashMessage(txx.Transaction.RawData); !bytes.Equal(txId, txx.Txid) {
+		t.Fatal("txid mismatch")
+	}
+	if _, err = client.BuildTriggerTx(context.Background(), owner, contract, 0, nil,
+		&TxOptions{Expiration: MaxTxExpiration + time.Millisecond}); err == nil {
+		t.Fatal("expecting expiration too long")
+	}
+}
+
+func TestHttpClient_GetTransactionSignWeight(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		if r.URL.Path != "/wallet/getsignweight" || req["raw_data_hex"] == nil {
+			t.Errorf("unexpected request %s %v", r.URL.Path, req)
+		}
+		_, _ = w.Write([]byte(`{"result":{"code":"NOT_ENOUGH_PERMISSION"},"current_weight":2,"permission":{"type":"Active","id":2,"threshold":3}}`))
+	}))
+	defer server.Close()
+	tx := testTransferTx(t, testSigners(t, 1)[0].Address(), 2)
+	sw, err := NewHttpClient(server.URL, 1).GetTransactionSignWeight(context.Background(), (*core.Transaction)(tx))
+	if err != nil || sw.CurrentWeight != 2 || sw.Permission.GetThreshold() != 3 ||
+		sw.Result.GetCode() != api.TransactionSignWeight_Result_NOT_ENOUGH_PERMISSION {
+		t.Fatalf("unexpected sign weight %v %v", sw, err)
+	}
+}
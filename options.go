@@ -0,0 +1,252 @@
+package go_tronsdk
+
+import (
+	"crypto/tls"
+	"math/big"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+type Transport string
+
+const (
+	TransportHTTP    Transport = "http"
+	TransportGRPC    Transport = "grpc"
+	TransportJSONRPC Transport = "jsonrpc"
+	// TransportSolidity is the gRPC or HTTP API of solidity nodes
+	TransportSolidity Transport = "solidity"
+)
+
+type clientOptions struct {
+	httpUrl         string
+	grpcUrls        []string
+	ethUrl          string
+	timeout         time.Duration
+	getTxInterval   time.Duration
+	dialOptions     []grpc.DialOption
+	chainID         *big.Int
+	expectedChainID *big.Int
+	tlsConfig       *tls.Config
+	headers         HeaderProvider
+	balancer        Balancer
+	health          HealthConfig
+	quorum          int
+	backends        []namedBackend
+	solidityGrpc    []string
+	solidityHttp    string
+	retry           *RetryPolicy
+	limiter         *RateLimiter
+	breaker         *BreakerConfig
+	feeLimitMargin  float64
+}
+
+type namedBackend struct {
+	name    string
+	backend Backend
+}
+
+// Option configures a TronClient created by NewClient.
+type Option func(*clientOptions)
+
+func defaultClientOptions() *clientOptions {
+	return &clientOptions{
+		timeout:        DefaultTimeoutSeconds * time.Second,
+		getTxInterval:  DefaultGetTxIntervalSeconds * time.Second,
+		feeLimitMargin: DefaultFeeLimitMargin,
+	}
+}
+
+// WithHTTP enables the fullnode HTTP API transport, e.g. https://api.trongrid.io. If there is
+// no gRPC endpoint nor backend, it is used as the fullnode backend.
+func WithHTTP(url string) Option {
+	return func(o *clientOptions) {
+		o.httpUrl = url
+	}
+}
+
+// WithGRPC enables the fullnode gRPC transport, e.g. grpc.trongrid.io:50051. It could be used
+// more than once, same as WithGRPCEndpoints.
+func WithGRPC(url string) Option {
+	return WithGRPCEndpoints(url)
+}
+
+// WithGRPCEndpoints adds fullnode gRPC endpoints, calls are failed over to the next healthy
+// endpoint when one fails, in the order they are added.
+func WithGRPCEndpoints(urls ...string) Option {
+	return func(o *clientOptions) {
+		for _, url := range urls {
+			if url != "" {
+				o.grpcUrls = append(o.grpcUrls, url)
+			}
+		}
+	}
+}
+
+// WithBalancer sets how idempotent queries are spread over the gRPC endpoints, BalanceFailover by default
+func WithBalancer(b Balancer) Option {
+	return func(o *clientOptions) {
+		o.balancer = b
+	}
+}
+
+// WithHealthCheck sets how the health of gRPC endpoints is judged, only used with multiple endpoints
+func WithHealthCheck(h HealthConfig) Option {
+	return func(o *clientOptions) {
+		o.health = h
+	}
+}
+
+// WithJSONRPC enables the ETH compatible JSON-RPC transport, e.g. https://api.trongrid.io/jsonrpc
+func WithJSONRPC(url string) Option {
+	return func(o *clientOptions) {
+		o.ethUrl = url
+	}
+}
+
+// WithTimeout sets the timeout of each remote call, non-positive values are ignored.
+func WithTimeout(d time.Duration) Option {
+	return func(o *clientOptions) {
+		if d > 0 {
+			o.timeout = d
+		}
+	}
+}
+
+// WithGetTxInterval sets the polling interval used by TryTxByHash, non-positive values are ignored.
+func WithGetTxInterval(d time.Duration) Option {
+	return func(o *clientOptions) {
+		if d > 0 {
+			o.getTxInterval = d
+		}
+	}
+}
+
+// WithDialOptions appends options used when dialing the gRPC transport.
+func WithDialOptions(opts ...grpc.DialOption) Option {
+	return func(o *clientOptions) {
+		o.dialOptions = append(o.dialOptions, opts...)
+	}
+}
+
+// WithExpectedChainID makes NewClient fail if the chain id reported by the node is different,
+// which is the one of JSON-RPC or the genesis block. If no transport can report a chain id, the
+// expected one is used.
+func WithExpectedChainID(chainId *big.Int) Option {
+	return func(o *clientOptions) {
+		if chainId != nil {
+			o.expectedChainID = new(big.Int).Set(chainId)
+		}
+	}
+}
+
+// WithChainID uses chainId as the chain id without asking the nodes, which saves the genesis
+// block fetch of NewClient but trusts the nodes to be of the chain. See WithExpectedChainID.
+func WithChainID(chainId *big.Int) Option {
+	return func(o *clientOptions) {
+		if chainId != nil {
+			o.chainID = new(big.Int).Set(chainId)
+		}
+	}
+}
+
+// WithTLS enables TLS of the gRPC transport and uses cfg for the https connections of HTTP and
+// JSON-RPC transports. A nil cfg means TLS with the system roots.
+func WithTLS(cfg *tls.Config) Option {
+	return func(o *clientOptions) {
+		if cfg == nil {
+			o.tlsConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+		} else {
+			o.tlsConfig = cfg.Clone()
+		}
+	}
+}
+
+// WithHeaderProvider authenticates every request of all transports with the headers provided
+// by p, which are sent as metadata of gRPC calls.
+func WithHeaderProvider(p HeaderProvider) Option {
+	return func(o *clientOptions) {
+		o.headers = p
+	}
+}
+
+// WithAPIKey authenticates every request with the TRON-PRO-API-KEY header, as TronGrid requires
+func WithAPIKey(key string) Option {
+	return WithHeaderProvider(APIKey(key))
+}
+
+// WithQuorum makes ListCommittees, GetBlock, GetBlockHeader and GetTransactionInfoById ask all
+// the gRPC endpoints, and return the result only if at least m of them agree and no other result
+// does, otherwise a *DivergenceError. m should not be greater than the number of gRPC endpoints,
+// and more than half of them to tolerate the divergent ones.
+func WithQuorum(m int) Option {
+	return func(o *clientOptions) {
+		o.quorum = m
+	}
+}
+
+// WithBackend adds a fullnode backend, such as a mock in tests, after the gRPC endpoints.
+// The backend is not closed by TronClient.
+func WithBackend(name string, b Backend) Option {
+	return func(o *clientOptions) {
+		if b != nil {
+			o.backends = append(o.backends, namedBackend{name: name, backend: b})
+		}
+	}
+}
+
+// WithSolidityGRPC adds gRPC endpoints of solidity nodes (WalletSolidity), e.g. grpc.trongrid.io:50052,
+// which answer the queries with Confirmed consistency.
+func WithSolidityGRPC(urls ...string) Option {
+	return func(o *clientOptions) {
+		for _, url := range urls {
+			if url != "" {
+				o.solidityGrpc = append(o.solidityGrpc, url)
+			}
+		}
+	}
+}
+
+// WithSolidityHTTP sets the base url of the /walletsolidity/* HTTP API, e.g. https://api.trongrid.io,
+// which is used for Confirmed queries if there is no solidity gRPC endpoint.
+func WithSolidityHTTP(url string) Option {
+	return func(o *clientOptions) {
+		o.solidityHttp = url
+	}
+}
+
+// WithRetry retries the failed calls of TronClient with p, such as DefaultRetryPolicy(). Each
+// attempt fails over the endpoints before backing off. The method names are the ones of
+// TronClient, such as "GetAccount".
+func WithRetry(p *RetryPolicy) Option {
+	return func(o *clientOptions) {
+		o.retry = p
+	}
+}
+
+// WithRateLimiter limits the calls to each gRPC, HTTP and JSON-RPC endpoint with l. The limiter
+// could be shared with other clients, and be observed by l.Budgets().
+func WithRateLimiter(l *RateLimiter) Option {
+	return func(o *clientOptions) {
+		o.limiter = l
+	}
+}
+
+// WithCircuitBreaker puts a circuit breaker configured by cfg in front of each gRPC, HTTP and
+// JSON-RPC endpoint. Calls to an endpoint with open breaker fail fast with *BreakerOpenError,
+// or fail over to the next endpoint.
+func WithCircuitBreaker(cfg BreakerConfig) Option {
+	return func(o *clientOptions) {
+		o.breaker = &cfg
+	}
+}
+
+// WithFeeLimitMargin sets the default safety margin of AutoFeeLimit, e.g. 0.2 for 20% more than
+// the estimated fee. DefaultFeeLimitMargin is used if not set.
+func WithFeeLimitMargin(margin float64) Option {
+	return func(o *clientOptions) {
+		if margin >= 0 {
+			o.feeLimitMargin = margin
+		}
+	}
+}
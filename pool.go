@@ -0,0 +1,331 @@
+package go_tronsdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type Balancer int
+
+const (
+	// BalanceFailover always uses the first healthy endpoint in configured order
+	BalanceFailover Balancer = iota
+	// BalanceRoundRobin spreads idempotent queries over healthy endpoints in turn
+	BalanceRoundRobin
+	// BalanceLeastLatency sends idempotent queries to the healthy endpoint with the lowest latency
+	BalanceLeastLatency
+)
+
+func (b Balancer) String() string {
+	switch b {
+	case BalanceFailover:
+		return "Failover"
+	case BalanceRoundRobin:
+		return "RoundRobin"
+	case BalanceLeastLatency:
+		return "LeastLatency"
+	default:
+		return fmt.Sprintf("Balancer(%d)", int(b))
+	}
+}
+
+// HealthConfig decides whether a fullnode endpoint is healthy. Zero values use the defaults.
+type HealthConfig struct {
+	// Interval of probing the height of each endpoint with GetNowBlock, negative disables probing
+	Interval time.Duration
+	// MaxLag is the max number of blocks an endpoint can fall behind the highest one
+	MaxLag int64
+	// MaxFailureRate is the max rate of failed calls in the recent Window calls
+	MaxFailureRate float64
+	Window         int
+}
+
+const (
+	DefaultHealthInterval       = 10 * time.Second
+	DefaultHealthMaxLag         = 20
+	DefaultHealthMaxFailureRate = 0.5
+	DefaultHealthWindow         = 20
+	minFailureSamples           = 5
+)
+
+func (h HealthConfig) withDefaults() HealthConfig {
+	if h.Interval == 0 {
+		h.Interval = DefaultHealthInterval
+	}
+	if h.MaxLag <= 0 {
+		h.MaxLag = DefaultHealthMaxLag
+	}
+	if h.MaxFailureRate <= 0 {
+		h.MaxFailureRate = DefaultHealthMaxFailureRate
+	}
+	if h.Window <= 0 {
+		h.Window = DefaultHealthWindow
+	}
+	return h
+}
+
+type fullnode struct {
+	url     string
+	backend Backend
+	closer  func() error // only for the backends created by TronClient
+	breaker *CircuitBreaker
+
+	lock     sync.Mutex
+	height   int64
+	lag      int64
+	probeErr error
+	latency  time.Duration // moving average
+	results  []bool        // ring buffer of recent call results, true for failure
+	resIdx   int
+}
+
+func (n *fullnode) record(failed bool, d time.Duration, window int) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	if len(n.results) < window {
+		n.results = append(n.results, failed)
+	} else {
+		n.results[n.resIdx] = failed
+		n.resIdx = (n.resIdx + 1) % window
+	}
+	if !failed {
+		if n.latency == 0 {
+			n.latency = d
+		} else {
+			n.latency = (n.latency*7 + d) / 8
+		}
+	}
+}
+
+func (n *fullnode) failureRate() float64 {
+	if len(n.results) < minFailureSamples {
+		return 0
+	}
+	failures := 0
+	for _, failed := range n.results {
+		if failed {
+			failures++
+		}
+	}
+	return float64(failures) / float64(len(n.results))
+}
+
+func (n *fullnode) healthy(h HealthConfig) bool {
+	if n.breaker.State() == BreakerOpen {
+		return false
+	}
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	return n.probeErr == nil && n.lag <= h.MaxLag && n.failureRate() <= h.MaxFailureRate
+}
+
+func (n *fullnode) getLatency() time.Duration {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	return n.latency
+}
+
+func (n *fullnode) String() string {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	return fmt.Sprintf("{%s Height:%d Lag:%d Latency:%s FailureRate:%.2f ProbeErr:%v}",
+		n.url, n.height, n.lag, n.latency, n.failureRate(), n.probeErr)
+}
+
+// nodePool holds the fullnode backends, calls are failed over to the next endpoint when
+// the current one has a transport failure.
+type nodePool struct {
+	nodes    []*fullnode
+	balancer Balancer
+	health   HealthConfig
+	next     atomic.Uint64
+	limiter  *RateLimiter
+	stop     chan struct{}
+	wg       sync.WaitGroup
+}
+
+func newNodePool(nodes []*fullnode, balancer Balancer, health HealthConfig) *nodePool {
+	p := &nodePool{
+		nodes:    nodes,
+		balancer: balancer,
+		health:   health.withDefaults(),
+		stop:     make(chan struct{}),
+	}
+	if len(nodes) > 1 && p.health.Interval > 0 {
+		p.wg.Add(1)
+		go p.probeLoop()
+	}
+	return p
+}
+
+func (p *nodePool) setBreakers(cfg *BreakerConfig) {
+	if cfg == nil {
+		return
+	}
+	for _, n := range p.nodes {
+		n.breaker = NewCircuitBreaker(n.url, *cfg)
+	}
+}
+
+func (p *nodePool) Close() error {
+	close(p.stop)
+	p.wg.Wait()
+	var errs []error
+	for _, n := range p.nodes {
+		if n.closer != nil {
+			if err := n.closer(); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (p *nodePool) probeLoop() {
+	defer p.wg.Done()
+	ticker := time.NewTicker(p.health.Interval)
+	defer ticker.Stop()
+	for {
+		p.probe()
+		select {
+		case <-p.stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (p *nodePool) probe() {
+	var wg sync.WaitGroup
+	for _, n := range p.nodes {
+		wg.Add(1)
+		go func(n *fullnode) {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(context.Background(), p.health.Interval)
+			defer cancel()
+			start := time.Now()
+			blk, err := n.backend.GetNowBlock(ctx)
+			if err == nil && (blk == nil || blk.BlockHeader == nil || blk.BlockHeader.RawData == nil) {
+				err = errors.New("empty block")
+			}
+			n.lock.Lock()
+			n.probeErr = err
+			if err == nil {
+				n.height = blk.BlockHeader.RawData.Number
+			}
+			n.lock.Unlock()
+			n.record(err != nil, time.Since(start), p.health.Window)
+		}(n)
+	}
+	wg.Wait()
+
+	var highest int64
+	for _, n := range p.nodes {
+		n.lock.Lock()
+		if n.height > highest {
+			highest = n.height
+		}
+		n.lock.Unlock()
+	}
+	for _, n := range p.nodes {
+		n.lock.Lock()
+		n.lag = highest - n.height
+		n.lock.Unlock()
+	}
+}
+
+// order returns the endpoints to try in turn, healthy ones first. Only idempotent
+// queries are balanced, others always start from the first healthy endpoint.
+func (p *nodePool) order(balanced bool) []*fullnode {
+	healthy := make([]*fullnode, 0, len(p.nodes))
+	var unhealthy []*fullnode
+	for _, n := range p.nodes {
+		if n.healthy(p.health) {
+			healthy = append(healthy, n)
+		} else {
+			unhealthy = append(unhealthy, n)
+		}
+	}
+	if balanced && len(healthy) > 1 {
+		switch p.balancer {
+		case BalanceRoundRobin:
+			i := int(p.next.Add(1)-1) % len(healthy)
+			healthy = append(healthy[i:], healthy[:i]...)
+		case BalanceLeastLatency:
+			sort.SliceStable(healthy, func(i, j int) bool {
+				return healthy[i].getLatency() < healthy[j].getLatency()
+			})
+		}
+	}
+	return append(healthy, unhealthy...)
+}
+
+// isTransportError reports whether err is caused by the endpoint rather than the request,
+// so that the call could be sent to another endpoint.
+func isTransportError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var herr HTTPError
+	if errors.As(err, &herr) {
+		return herr.StatusCode == http.StatusTooManyRequests || herr.StatusCode >= 500
+	}
+	var nerr net.Error
+	if errors.As(err, &nerr) {
+		return true
+	}
+	s, ok := status.FromError(err)
+	if !ok {
+		return false
+	}
+	switch s.Code() {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Aborted, codes.Internal, codes.Unknown:
+		return true
+	default:
+		return false
+	}
+}
+
+func _poolRun[T any](ctx context.Context, p *nodePool, method string, timeout time.Duration, balanced bool,
+	f func(context.Context, Backend) (T, error)) (t T, err error) {
+	nodes := p.order(balanced)
+	for i, n := range nodes {
+		// endpoints with open breaker are skipped
+		if err = n.breaker.Allow(); err == nil {
+			if err = p.limiter.Wait(ctx, n.url, method); err != nil {
+				n.breaker.cancel()
+				return t, err
+			}
+			start := time.Now()
+			t, err = _timeoutRun(ctx, timeout, func(cctx context.Context) (T, error) {
+				return f(cctx, n.backend)
+			})
+			failed := isTransportError(err)
+			n.record(failed, time.Since(start), p.health.Window)
+			n.breaker.Record(failed)
+			if !failed || ctx.Err() != nil {
+				return t, err
+			}
+		}
+		if i < len(nodes)-1 {
+			continue
+		}
+		if len(nodes) > 1 {
+			return t, fmt.Errorf("all %d fullnodes failed, last %s: %w", len(nodes), n.url, err)
+		}
+	}
+	return t, err
+}
@@ -0,0 +1,126 @@
+package go_tronsdk
+
+import (
+	"context"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type fakeWallet struct {
+	api.WalletClient
+	name   string
+	height int64
+	err    error
+	calls  int
+
+	inactive   bool // accounts not exist
+	broadcasts []*core.Transaction
+}
+
+func (w *fakeWallet) GetNowBlock2(_ context.Context, _ *api.EmptyMessage, _ ...grpc.CallOption) (*api.BlockExtention, error) {
+	if w.err != nil {
+		return nil, w.err
+	}
+	blockId := make([]byte, 32)
+	blockId[31] = byte(w.height)
+	return &api.BlockExtention{
+		BlockHeader: &core.BlockHeader{RawData: &core.BlockHeaderRaw{Number: w.height, Timestamp: 1700000000000}},
+		Blockid:     blockId,
+	}, nil
+}
+
+func (w *fakeWallet) GetBlock(_ context.Context, in *api.BlockReq, _ ...grpc.CallOption) (*api.BlockExtention, error) {
+	if w.err != nil {
+		return nil, w.err
+	}
+	if in.IdOrNum != "0" {
+		return nil, status.Error(codes.NotFound, "block not found")
+	}
+	blockId, _ := hex.DecodeString("00000000000000001ebf88508a03865c71d452e25f4d51194196a1d22b6653dc")
+	return &api.BlockExtention{Blockid: blockId}, nil
+}
+
+func (w *fakeWallet) GetAccount(_ context.Context, in *core.Account, _ ...grpc.CallOption) (*core.Account, error) {
+	w.calls++
+	if w.err != nil {
+		return nil, w.err
+	}
+	if w.inactive {
+		return &core.Account{}, nil
+	}
+	return &core.Account{Address: in.GetAddress(), AccountName: []byte(w.name)}, nil
+}
+
+func (w *fakeWallet) BroadcastTransaction(_ context.Context, in *core.Transaction, _ ...grpc.CallOption) (*api.Return, error) {
+	if w.err != nil {
+		return nil, w.err
+	}
+	w.broadcasts = append(w.broadcasts, in)
+	return &api.Return{Result: true}, nil
+}
+
+func newFakePool(balancer Balancer, wallets ...*fakeWallet) *nodePool {
+	var nodes []*fullnode
+	for _, w := range wallets {
+		nodes = append(nodes, &fullnode{url: w.name, backend: NewGrpcBackend(w)})
+	}
+	return newNodePool(nodes, balancer, HealthConfig{Interval: -1})
+}
+
+func TestNodePool_Failover(t *testing.T) {
+	down := &fakeWallet{name: "down", err: status.Error(codes.Unavailable, "down")}
+	up := &fakeWallet{name: "up"}
+	p := newFakePool(BalanceFailover, down, up)
+	defer p.Close()
+	for i := 0; i < minFailureSamples+1; i++ {
+		acc, err := _poolRun(context.Background(), p, "GetAccount", time.Second, true, func(ctx context.Context, b Backend) (*core.Account, error) {
+			return b.GetAccount(ctx, nil)
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(acc.AccountName) != "up" {
+			t.Fatalf("expecting answer from up, got %s", acc.AccountName)
+		}
+	}
+	if down.calls != minFailureSamples {
+		t.Fatalf("unhealthy endpoint should be skipped after %d failures, but called %d times", minFailureSamples, down.calls)
+	}
+
+	notFound := status.Error(codes.NotFound, "not found")
+	down.err, up.err = notFound, nil
+	p2 := newFakePool(BalanceFailover, down, up)
+	defer p2.Close()
+	if _, err := _poolRun(context.Background(), p2, "GetAccount", time.Second, true, func(ctx context.Context, b Backend) (*core.Account, error) {
+		return b.GetAccount(ctx, nil)
+	}); err != notFound {
+		t.Fatalf("request errors should not fail over, got %v", err)
+	}
+}
+
+func TestNodePool_Balance(t *testing.T) {
+	x, y, lagging := &fakeWallet{name: "x", height: 100}, &fakeWallet{name: "y", height: 99}, &fakeWallet{name: "z", height: 10}
+	p := newFakePool(BalanceRoundRobin, x, y, lagging)
+	defer p.Close()
+	p.probe()
+	for i := 0; i < 4; i++ {
+		if _, err := _poolRun(context.Background(), p, "GetAccount", time.Second, true, func(ctx context.Context, b Backend) (*core.Account, error) {
+			return b.GetAccount(ctx, nil)
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if x.calls != 2 || y.calls != 2 || lagging.calls != 0 {
+		t.Fatalf("round robin calls x:%d y:%d z:%d", x.calls, y.calls, lagging.calls)
+	}
+	if order := p.order(false); order[0].url != "x" || order[2].url != "z" {
+		t.Fatalf("unexpected order %v", order)
+	}
+}
@@ -0,0 +1,140 @@
+package go_tronsdk
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// NodeAnswer is the answer of one endpoint in a quorum read, Hash is nil if Err is not nil.
+type NodeAnswer struct {
+	Endpoint string
+	Hash     []byte
+	Result   interface{}
+	Err      error
+}
+
+func (a NodeAnswer) String() string {
+	if a.Err != nil {
+		return fmt.Sprintf("{%s Err:%v}", a.Endpoint, a.Err)
+	}
+	return fmt.Sprintf("{%s Hash:%x}", a.Endpoint, a.Hash)
+}
+
+// DivergenceError is returned by quorum reads when less than Required endpoints agree on the result,
+// or when more than one result are agreed by Required endpoints.
+type DivergenceError struct {
+	Method   string
+	Required int
+	Answers  []NodeAnswer
+}
+
+func (e *DivergenceError) Error() string {
+	return fmt.Sprintf("%s: not exactly one result agreed by %d of %d endpoints, answers: %s",
+		e.Method, e.Required, len(e.Answers), e.Answers)
+}
+
+// hashProto hashes the deterministic encoding of t, so that equal answers are hashed the same
+// even with map fields
+func hashProto[T proto.Message](t T) ([]byte, error) {
+	bs, err := proto.MarshalOptions{Deterministic: true}.Marshal(t)
+	if err != nil {
+		return nil, err
+	}
+	h := sha256.Sum256(bs)
+	return h[:], nil
+}
+
+func hashWitnessPerms(wps []*WitnessPerm) ([]byte, error) {
+	hasher := sha256.New()
+	for _, wp := range wps {
+		hasher.Write(wp.OwnerAddr)
+		hasher.Write([]byte{0})
+		hasher.Write(wp.WitnessAddr)
+		hasher.Write([]byte{0})
+	}
+	return hasher.Sum(nil), nil
+}
+
+// _quorumRun runs f on all fullnode endpoints concurrently, and returns the result at least
+// c.quorum of them agree on, which are compared by the hash of the results. It is a divergence
+// if another result is agreed by c.quorum endpoints too.
+func _quorumRun[T any](ctx context.Context, c *TronClient, method string, hash func(T) ([]byte, error),
+	f func(context.Context, Backend) (T, error)) (t T, err error) {
+	if c.fullnodes == nil {
+		return t, TransportUnavailableError{Transport: TransportGRPC}
+	}
+	nodes := c.fullnodes.nodes
+	answers := make([]NodeAnswer, len(nodes))
+	results := make([]T, len(nodes))
+	var wg sync.WaitGroup
+	for i, n := range nodes {
+		wg.Add(1)
+		go func(i int, n *fullnode) {
+			defer wg.Done()
+			answers[i].Endpoint = n.url
+			if err := n.breaker.Allow(); err != nil {
+				answers[i].Err = err
+				return
+			}
+			if err := c.fullnodes.limiter.Wait(ctx, n.url, method); err != nil {
+				n.breaker.cancel()
+				answers[i].Err = err
+				return
+			}
+			r, err := f(ctx, n.backend)
+			n.breaker.Record(isTransportError(err))
+			if err == nil {
+				answers[i].Hash, err = hash(r)
+			}
+			if err != nil {
+				answers[i].Err = err
+				return
+			}
+			answers[i].Result = r
+			results[i] = r
+		}(i, n)
+	}
+	wg.Wait()
+
+	// the result must be the only one agreed by c.quorum endpoints, otherwise a tied split such
+	// as A,A,B,B of quorum 2 would return the answer of whichever endpoint listed first
+	best := -1
+	for i := range answers {
+		if answers[i].Err != nil {
+			continue
+		}
+		count := 0
+		for j := range answers {
+			if answers[j].Err == nil && bytes.Equal(answers[i].Hash, answers[j].Hash) {
+				count++
+			}
+		}
+		if count < c.quorum {
+			continue
+		}
+		if best >= 0 && !bytes.Equal(answers[best].Hash, answers[i].Hash) {
+			best = -1
+			break
+		}
+		best = i
+	}
+	if best >= 0 {
+		return results[best], nil
+	}
+	return t, &DivergenceError{Method: method, Required: c.quorum, Answers: answers}
+}
+
+// _withTimeout applies the call timeout to f on each endpoint
+func _withTimeout[T any](d time.Duration, f func(context.Context, Backend) (T, error)) func(context.Context, Backend) (T, error) {
+	return func(ctx context.Context, b Backend) (T, error) {
+		return _timeoutRun(ctx, d, func(cctx context.Context) (T, error) {
+			return f(cctx, b)
+		})
+	}
+}
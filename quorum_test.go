@@ -0,0 +1,48 @@
+package go_tronsdk
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+)
+
+func TestQuorumRun(t *testing.T) {
+	getAccount := func(ctx context.Context, b Backend) (*core.Account, error) {
+		return b.GetAccount(ctx, nil)
+	}
+	c := &TronClient{
+		timeout: time.Second,
+		quorum:  2,
+		fullnodes: newFakePool(BalanceFailover,
+			&fakeWallet{name: "x"}, &fakeWallet{name: "y"}, &fakeWallet{name: "x"}),
+	}
+	acc, err := _quorumRun(context.Background(), c, "GetAccount", hashProto[*core.Account], getAccount)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(acc.AccountName) != "x" {
+		t.Fatalf("expecting x, got %s", acc.AccountName)
+	}
+
+	c.quorum = 3
+	_, err = _quorumRun(context.Background(), c, "GetAccount", hashProto[*core.Account], getAccount)
+	var de *DivergenceError
+	if !errors.As(err, &de) {
+		t.Fatalf("expecting DivergenceError, got %v", err)
+	}
+	if len(de.Answers) != 3 || de.Answers[1].Result.(*core.Account) == nil {
+		t.Fatalf("answers missing: %v", de.Answers)
+	}
+	t.Log(err)
+
+	// a tied split is a divergence, whichever endpoint is listed first
+	c.quorum = 2
+	c.fullnodes = newFakePool(BalanceFailover,
+		&fakeWallet{name: "x"}, &fakeWallet{name: "x"}, &fakeWallet{name: "y"}, &fakeWallet{name: "y"})
+	if _, err = _quorumRun(context.Background(), c, "GetAccount", hashProto[*core.Account], getAccount); !errors.As(err, &de) {
+		t.Fatalf("expecting DivergenceError of the tied split, got %v", err)
+	}
+}
@@ -0,0 +1,265 @@
+package go_tronsdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MethodClass groups the methods sharing a rate limit budget
+type MethodClass string
+
+const (
+	MethodRead      MethodClass = "read"
+	MethodBroadcast MethodClass = "broadcast"
+	MethodLogQuery  MethodClass = "logquery"
+)
+
+// methods other than MethodRead, in lower case
+var defaultMethodClasses = map[string]MethodClass{
+	"broadcasttransaction":         MethodBroadcast,
+	"broadcasthex":                 MethodBroadcast,
+	"filterlogs":                   MethodLogQuery,
+	"gettransactioninfobyblocknum": MethodLogQuery,
+}
+
+var ErrRateLimitExceeded = errors.New("rate limit budget exceeds deadline")
+
+// RateLimit allows QPS requests per second on average and bursts of Burst requests.
+// Non-positive QPS means unlimited.
+type RateLimit struct {
+	QPS   float64
+	Burst int
+}
+
+// TokenBucket is a token bucket limiter. Waiters reserve tokens in the order they arrive, so
+// that they are served fairly.
+type TokenBucket struct {
+	lock   sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func NewTokenBucket(limit RateLimit) *TokenBucket {
+	burst := float64(limit.Burst)
+	if burst < 1 {
+		burst = 1
+	}
+	return &TokenBucket{rate: limit.QPS, burst: burst, tokens: burst, last: time.Now()}
+}
+
+// advance must be called with lock held
+func (b *TokenBucket) advance(now time.Time) {
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens = math.Min(b.burst, b.tokens+elapsed.Seconds()*b.rate)
+		b.last = now
+	}
+}
+
+// Wait blocks until a token is available. If the token could not be available before the
+// deadline of ctx, it returns ErrRateLimitExceeded at once without taking the token.
+func (b *TokenBucket) Wait(ctx context.Context) error {
+	if b.rate <= 0 {
+		return ctx.Err()
+	}
+	b.lock.Lock()
+	now := time.Now()
+	b.advance(now)
+	var delay time.Duration
+	if b.tokens < 1 {
+		delay = time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+	}
+	if deadline, ok := ctx.Deadline(); ok && now.Add(delay).After(deadline) {
+		b.lock.Unlock()
+		return fmt.Errorf("%w: wait %s", ErrRateLimitExceeded, delay)
+	}
+	b.tokens--
+	b.lock.Unlock()
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		// give back the reserved token
+		b.lock.Lock()
+		b.advance(time.Now())
+		b.tokens = math.Min(b.burst, b.tokens+1)
+		b.lock.Unlock()
+		return ctx.Err()
+	}
+}
+
+// Tokens returns the available tokens, negative if there are waiters
+func (b *TokenBucket) Tokens() float64 {
+	if b.rate <= 0 {
+		return math.Inf(1)
+	}
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.advance(time.Now())
+	return b.tokens
+}
+
+// Budget is the state of a token bucket of RateLimiter
+type Budget struct {
+	Endpoint string
+	Class    MethodClass
+	Limit    RateLimit
+	Tokens   float64
+}
+
+func (b Budget) String() string {
+	return fmt.Sprintf("{%s %s QPS:%g Burst:%d Tokens:%.2f}", b.Endpoint, b.Class, b.Limit.QPS, b.Limit.Burst, b.Tokens)
+}
+
+type bucketKey struct {
+	endpoint string
+	class    MethodClass
+}
+
+// RateLimiter holds a token bucket for each endpoint and method class. Limits should be set
+// before it is used by clients.
+type RateLimiter struct {
+	lock      sync.Mutex
+	limits    map[MethodClass]RateLimit
+	endpoints map[string]map[MethodClass]RateLimit
+	classes   map[string]MethodClass
+	buckets   map[bucketKey]*TokenBucket
+}
+
+func NewRateLimiter() *RateLimiter {
+	return &RateLimiter{
+		limits:    make(map[MethodClass]RateLimit),
+		endpoints: make(map[string]map[MethodClass]RateLimit),
+		classes:   make(map[string]MethodClass),
+		buckets:   make(map[bucketKey]*TokenBucket),
+	}
+}
+
+// SetLimit sets the limit of class for each endpoint
+func (l *RateLimiter) SetLimit(class MethodClass, limit RateLimit) *RateLimiter {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.limits[class] = limit
+	return l
+}
+
+// SetEndpointLimit sets the limit of class for endpoint, which overrides the one of SetLimit.
+// The endpoints are the urls given to the client, such as grpc.trongrid.io:50051.
+func (l *RateLimiter) SetEndpointLimit(endpoint string, class MethodClass, limit RateLimit) *RateLimiter {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	m, ok := l.endpoints[endpoint]
+	if !ok {
+		m = make(map[MethodClass]RateLimit)
+		l.endpoints[endpoint] = m
+	}
+	m[class] = limit
+	return l
+}
+
+// SetMethodClass puts method (case-insensitive) into class. Methods are MethodRead by default,
+// except the broadcasts and log queries.
+func (l *RateLimiter) SetMethodClass(method string, class MethodClass) *RateLimiter {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.classes[strings.ToLower(method)] = class
+	return l
+}
+
+func (l *RateLimiter) ClassOf(method string) MethodClass {
+	method = strings.ToLower(method)
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if class, ok := l.classes[method]; ok {
+		return class
+	}
+	if class, ok := defaultMethodClasses[method]; ok {
+		return class
+	}
+	return MethodRead
+}
+
+func (l *RateLimiter) bucket(endpoint string, class MethodClass) *TokenBucket {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	key := bucketKey{endpoint: endpoint, class: class}
+	if b, ok := l.buckets[key]; ok {
+		return b
+	}
+	limit, ok := l.endpoints[endpoint][class]
+	if !ok {
+		limit, ok = l.limits[class]
+	}
+	if !ok || limit.QPS <= 0 {
+		return nil
+	}
+	b := NewTokenBucket(limit)
+	l.buckets[key] = b
+	return b
+}
+
+// Wait blocks until method could be sent to endpoint, or ctx is done
+func (l *RateLimiter) Wait(ctx context.Context, endpoint, method string) error {
+	if l == nil {
+		return nil
+	}
+	b := l.bucket(endpoint, l.ClassOf(method))
+	if b == nil {
+		return nil
+	}
+	return b.Wait(ctx)
+}
+
+// Budgets returns the current budgets of the limited endpoints and classes which have been used
+func (l *RateLimiter) Budgets() []Budget {
+	if l == nil {
+		return nil
+	}
+	l.lock.Lock()
+	var ret []Budget
+	buckets := make([]*TokenBucket, 0, len(l.buckets))
+	for key, b := range l.buckets {
+		limit, ok := l.endpoints[key.endpoint][key.class]
+		if !ok {
+			limit = l.limits[key.class]
+		}
+		ret = append(ret, Budget{Endpoint: key.endpoint, Class: key.class, Limit: limit})
+		buckets = append(buckets, b)
+	}
+	l.lock.Unlock()
+	for i, b := range buckets {
+		ret[i].Tokens = b.Tokens()
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		if ret[i].Endpoint == ret[j].Endpoint {
+			return ret[i].Class < ret[j].Class
+		}
+		return ret[i].Endpoint < ret[j].Endpoint
+	})
+	return ret
+}
+
+// Budget returns the available tokens of method class for endpoint, +Inf if it is not limited
+func (l *RateLimiter) Budget(endpoint string, class MethodClass) float64 {
+	if l == nil {
+		return math.Inf(1)
+	}
+	b := l.bucket(endpoint, class)
+	if b == nil {
+		return math.Inf(1)
+	}
+	return b.Tokens()
+}
@@ -0,0 +1,104 @@
+package go_tronsdk
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+)
+
+func TestTokenBucket(t *testing.T) {
+	b := NewTokenBucket(RateLimit{QPS: 20, Burst: 2})
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		if err := b.Wait(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// 2 from burst, and 2 more at 20 QPS
+	if elapsed := time.Since(start); elapsed < 90*time.Millisecond || elapsed > 500*time.Millisecond {
+		t.Fatalf("unexpected elapsed %s", elapsed)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	start = time.Now()
+	if err := b.Wait(ctx); !errors.Is(err, ErrRateLimitExceeded) {
+		t.Fatalf("expecting ErrRateLimitExceeded, got %v", err)
+	}
+	if time.Since(start) > 5*time.Millisecond {
+		t.Fatal("should not wait when deadline could not be met")
+	}
+
+	if tokens := NewTokenBucket(RateLimit{}).Tokens(); !math.IsInf(tokens, 1) {
+		t.Fatalf("unlimited bucket expected, got %f", tokens)
+	}
+}
+
+func TestRateLimiter(t *testing.T) {
+	l := NewRateLimiter().
+		SetLimit(MethodRead, RateLimit{QPS: 1, Burst: 1}).
+		SetEndpointLimit("b", MethodRead, RateLimit{QPS: 1, Burst: 3})
+	if l.ClassOf("BroadcastTransaction") != MethodBroadcast || l.ClassOf("FilterLogs") != MethodLogQuery ||
+		l.ClassOf("getaccount") != MethodRead {
+		t.Fatal("unexpected method class")
+	}
+	l.SetMethodClass("GetBlocks", MethodLogQuery)
+	if l.ClassOf("getblocks") != MethodLogQuery {
+		t.Fatal("method class should be overridden")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if err := l.Wait(ctx, "a", "GetAccount"); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.Wait(ctx, "a", "GetAccount"); !errors.Is(err, ErrRateLimitExceeded) {
+		t.Fatalf("budget of a should be exhausted, got %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		if err := l.Wait(ctx, "b", "GetAccount"); err != nil {
+			t.Fatalf("endpoint limit of b should be used: %v", err)
+		}
+	}
+	if err := l.Wait(ctx, "a", "BroadcastTransaction"); err != nil {
+		t.Fatalf("broadcast is not limited: %v", err)
+	}
+	budgets := l.Budgets()
+	if len(budgets) != 2 || budgets[0].Endpoint != "a" || budgets[1].Limit.Burst != 3 || budgets[1].Tokens > 0.5 {
+		t.Fatalf("unexpected budgets %v", budgets)
+	}
+	if !math.IsInf(l.Budget("a", MethodBroadcast), 1) {
+		t.Fatal("unlimited class expected")
+	}
+	var none *RateLimiter
+	if none.Budgets() != nil || !math.IsInf(none.Budget("a", MethodRead), 1) || none.Wait(ctx, "a", "GetAccount") != nil {
+		t.Fatal("nil limiter should be unlimited")
+	}
+}
+
+func TestNodePool_RateLimit(t *testing.T) {
+	w := &fakeWallet{name: "w"}
+	p := newFakePool(BalanceFailover, w)
+	defer p.Close()
+	p.limiter = NewRateLimiter().SetLimit(MethodRead, RateLimit{QPS: 1, Burst: 1})
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	for i := 0; i < 2; i++ {
+		_, err := _poolRun(ctx, p, "GetAccount", time.Second, true, func(ctx context.Context, b Backend) (*core.Account, error) {
+			return b.GetAccount(ctx, nil)
+		})
+		if i == 0 && err != nil {
+			t.Fatal(err)
+		}
+		if i == 1 && !errors.Is(err, ErrRateLimitExceeded) {
+			t.Fatalf("expecting ErrRateLimitExceeded, got %v", err)
+		}
+	}
+	if w.calls != 1 {
+		t.Fatalf("limited call should not be sent, calls:%d", w.calls)
+	}
+}
@@ -0,0 +1,170 @@
+package go_tronsdk
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"google.golang.org/protobuf/proto"
+)
+
+var ErrRawDataRequired = errors.New("raw data of the transaction is required")
+
+// TxSigner is a Signer which needs the raw data of the transaction besides the txid, such as
+// RemoteSigner. It is preferred to SignTxID when signing transactions.
+type TxSigner interface {
+	Signer
+	SignTx(ctx context.Context, txid []byte, raw *core.TransactionRaw) ([]byte, error)
+}
+
+// signTx signs the transaction by signer, with the raw data if it is a TxSigner
+func signTx(ctx context.Context, signer Signer, txid []byte, raw *core.TransactionRaw) ([]byte, error) {
+	if ts, ok := signer.(TxSigner); ok {
+		return ts.SignTx(ctx, txid, raw)
+	}
+	return signer.SignTxID(ctx, txid)
+}
+
+// remoteSignRequest is the request of the signing service. The service must check that the
+// sha256 of raw_data_hex is the txid before signing.
+type remoteSignRequest struct {
+	Address    string `json:"address"` // base58
+	TxID       string `json:"txid"`
+	RawDataHex string `json:"raw_data_hex"` // protobuf of the raw data
+}
+
+type remoteSignResponse struct {
+	Signature string `json:"signature,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// RemoteSignError is the refusal of the signing service, such as rejected by its policies
+type RemoteSignError struct {
+	Status  int
+	Message string
+}
+
+func (e *RemoteSignError) Error() string {
+	return fmt.Sprintf("remote signer: %d %s", e.Status, e.Message)
+}
+
+// RemoteSigner signs transactions by a signing service over HTTP(S), such as SignerServer. The
+// txid is sent with the raw data of the transaction, so that the service could check what it
+// signs. Signatures are verified to be of the address before returned.
+type RemoteSigner struct {
+	url    string
+	addr   address.Address
+	client *http.Client
+}
+
+// NewRemoteSigner signs for addr by the service at url, such as https://signer:8443/sign.
+// tlsConfig is for mutual TLS, see LoadMTLSConfig.
+func NewRemoteSigner(url string, addr address.Address, tlsConfig *tls.Config) *RemoteSigner {
+	client := newHttpTransportClient(tlsConfig)
+	client.Timeout = DefaultTimeoutSeconds * time.Second
+	return &RemoteSigner{url: url, addr: addr, client: client}
+}
+
+func (s *RemoteSigner) Address() address.Address {
+	return s.addr
+}
+
+// SignTxID always fails, the service does not sign a txid without its raw data
+func (s *RemoteSigner) SignTxID(context.Context, []byte) ([]byte, error) {
+	return nil, ErrRawDataRequired
+}
+
+func (s *RemoteSigner) SignTx(ctx context.Context, txid []byte, raw *core.TransactionRaw) ([]byte, error) {
+	if len(txid) != 32 {
+		return nil, errors.New("txid should be 32 bytes")
+	}
+	if raw == nil {
+		return nil, ErrRawDataRequired
+	}
+	rawBytes, err := proto.Marshal(raw)
+	if err != nil {
+		return nil, err
+	}
+	body, err := json.Marshal(&remoteSignRequest{
+		Address:    s.addr.String(),
+		TxID:       hex.EncodeToString(txid),
+		RawDataHex: hex.EncodeToString(rawBytes),
+	})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("content-type", JsonContentType)
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, err
+	}
+	ret := new(remoteSignResponse)
+	if err = json.Unmarshal(data, ret); err != nil && resp.StatusCode == http.StatusOK {
+		return nil, fmt.Errorf("remote signer: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK || ret.Error != "" {
+		msg := ret.Error
+		if msg == "" {
+			msg = string(data)
+		}
+		return nil, &RemoteSignError{Status: resp.StatusCode, Message: msg}
+	}
+	sig, err := hex.DecodeString(ret.Signature)
+	if err != nil || len(sig) != 65 {
+		return nil, fmt.Errorf("remote signer: invalid signature %q", ret.Signature)
+	}
+	pub, err := crypto.SigToPub(txid, sig)
+	if err != nil || !bytes.Equal(address.PubkeyToAddress(*pub), s.addr) {
+		return nil, errors.New("remote signer: signature is not of the address")
+	}
+	return sig, nil
+}
+
+// LoadMTLSConfig loads the certificate and key of this side, and the CA verifying the peer. The
+// config is for a server requiring client certificates if server is true, or for a client
+// otherwise.
+func LoadMTLSConfig(certFile, keyFile, caFile string, server bool) (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, err
+	}
+	ca, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(ca) {
+		return nil, fmt.Errorf("no certificate found in %s", caFile)
+	}
+	cfg := &tls.Config{MinVersion: tls.VersionTLS12, Certificates: []tls.Certificate{cert}}
+	if server {
+		cfg.ClientCAs = pool
+		cfg.ClientAuth = tls.RequireAndVerifyClientCert
+	} else {
+		cfg.RootCAs = pool
+	}
+	return cfg, nil
+}
@@ -0,0 +1,202 @@
+package go_tronsdk
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"math"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+func TestRemoteSigner(t *testing.T) {
+	priv, _ := hex.DecodeString("4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318")
+	key, _ := NewPrivateKeySigner(priv)
+	limit := NewDailyValueLimit(5_000_000)
+	server := httptest.NewServer(NewSignerServer([]Signer{key},
+		AllowContractTypes(core.Transaction_Contract_TransferContract, core.Transaction_Contract_TriggerSmartContract),
+		limit))
+	defer server.Close()
+
+	w := &fakeWallet{name: "fake", height: 100}
+	client, err := NewClient(context.Background(), WithBackend("fake", NewGrpcBackend(w)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = client.Close()
+	}()
+	signer := NewRemoteSigner(server.URL, key.Address(), nil)
+	to, _ := hex.DecodeString(testToHex)
+
+	txx, err := client.TransferTRX(context.Background(), signer, to, 3_000_000, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(w.broadcasts) != 1 || len(txx.Transaction.Signature) != 1 || limit.Spent(key.Address()) != 3_000_000 {
+		t.Fatalf("expecting the transfer signed and broadcast, spent %d", limit.Spent(key.Address()))
+	}
+	var rerr *RemoteSignError
+	if _, err = client.TransferTRX(context.Background(), signer, to, 3_000_000, nil); !errors.As(err, &rerr) ||
+		rerr.Status != http.StatusForbidden {
+		t.Fatalf("expecting daily limit exceeded, got %v", err)
+	}
+	if _, err = client.FreezeBalanceV2(context.Background(), signer, 1_000_000, core.ResourceCode_ENERGY, nil); !errors.As(err, &rerr) ||
+		!strings.Contains(rerr.Message, "contract type") {
+		t.Fatalf("expecting contract type rejected, got %v", err)
+	}
+	if _, err = signer.SignTxID(context.Background(), txx.Txid); !errors.Is(err, ErrRawDataRequired) {
+		t.Fatalf("expecting raw data required, got %v", err)
+	}
+
+	// raw data not hashed to the txid
+	raw := txx.Transaction.RawData
+	raw.Expiration++
+	if _, err = signer.SignTx(context.Background(), txx.Txid, raw); !errors.As(err, &rerr) || rerr.Status != http.StatusBadRequest {
+		t.Fatalf("expecting txid mismatch, got %v", err)
+	}
+	raw.Expiration--
+	other := NewRemoteSigner(server.URL, to, nil)
+	if _, err = other.SignTx(context.Background(), txx.Txid, raw); !errors.As(err, &rerr) || rerr.Status != http.StatusNotFound {
+		t.Fatalf("expecting unknown signer, got %v", err)
+	}
+}
+
+func TestSignPolicies(t *testing.T) {
+	contract, _ := hex.DecodeString(testToHex)
+	owner, _ := hex.DecodeString(testOwnerHex)
+	call := func(to []byte, data []byte) *SignRequest {
+		param, _ := anypb.New(&core.TriggerSmartContract{OwnerAddress: owner, ContractAddress: to, Data: data})
+		return &SignRequest{Signer: owner, Raw: &core.TransactionRaw{Contract: []*core.Transaction_Contract{
+			{Type: core.Transaction_Contract_TriggerSmartContract, Parameter: param}}}}
+	}
+	contracts, selectors := AllowContracts(contract), AllowSelectors(trc20Transfer)
+	transfer := append(append([]byte{}, trc20Transfer...), bytes.Repeat([]byte{0}, 64)...)
+	if err := contracts.Check(context.Background(), call(contract, transfer)); err != nil {
+		t.Fatal(err)
+	}
+	if err := contracts.Check(context.Background(), call(owner, transfer)); !errors.Is(err, ErrPolicyRejected) {
+		t.Fatalf("expecting contract rejected, got %v", err)
+	}
+	if err := selectors.Check(context.Background(), call(contract, transfer)); err != nil {
+		t.Fatal(err)
+	}
+	if err := selectors.Check(context.Background(), call(contract, trc20Approve)); !errors.Is(err, ErrPolicyRejected) {
+		t.Fatalf("expecting selector rejected, got %v", err)
+	}
+	if err := selectors.Check(context.Background(), call(contract, nil)); !errors.Is(err, ErrPolicyRejected) {
+		t.Fatalf("expecting call without selector rejected, got %v", err)
+	}
+
+	limit := NewDailyValueLimit(5_000_000)
+	send := func(amounts ...int64) *SignRequest {
+		req := &SignRequest{Signer: owner, Raw: &core.TransactionRaw{}}
+		for _, amount := range amounts {
+			param, _ := anypb.New(&core.TransferContract{OwnerAddress: owner, ToAddress: contract, Amount: amount})
+			req.Raw.Contract = append(req.Raw.Contract, &core.Transaction_Contract{
+				Type: core.Transaction_Contract_TransferContract, Parameter: param})
+		}
+		return req
+	}
+	limit.Commit(send(3_000_000))
+	for _, req := range []*SignRequest{send(-1_000_000), send(-1_000_000, 3_000_000), send()} {
+		if err := limit.Check(context.Background(), req); !errors.Is(err, ErrBadSignRequest) {
+			t.Fatalf("expecting %v rejected, got %v", req.Raw, err)
+		}
+		limit.Commit(req)
+	}
+	if err := limit.Check(context.Background(), send(math.MaxInt64)); !errors.Is(err, ErrPolicyRejected) {
+		t.Fatalf("expecting overflow rejected, got %v", err)
+	}
+	if spent := limit.Spent(owner); spent != 3_000_000 {
+		t.Fatalf("expecting 3000000 spent, got %d", spent)
+	}
+
+	// the values of tokens and other contracts
+	token := address.Address(contract).String()
+	calldata := func(selector []byte, words int, value *big.Int) []byte {
+		data := append(append([]byte{}, selector...), make([]byte, 32*words)...)
+		value.FillBytes(data[len(data)-32:])
+		return data
+	}
+	withContract := func(typ core.Transaction_Contract_ContractType, m proto.Message) *SignRequest {
+		param, _ := anypb.New(m)
+		return &SignRequest{Signer: owner, Raw: &core.TransactionRaw{Contract: []*core.Transaction_Contract{
+			{Type: typ, Parameter: param}}}}
+	}
+	if err := limit.Check(context.Background(), call(contract, calldata(trc20Transfer, 2, big.NewInt(1)))); !errors.Is(err, ErrPolicyRejected) {
+		t.Fatalf("expecting TRC20 without limit rejected, got %v", err)
+	}
+	limit.WithToken(token, 100).WithToken("1002000", 10)
+	for _, req := range []*SignRequest{
+		call(contract, calldata(trc20Transfer, 2, big.NewInt(60))),
+		call(contract, calldata(trc20TransferFrom, 3, big.NewInt(30))),
+		withContract(core.Transaction_Contract_TransferAssetContract,
+			&core.TransferAssetContract{AssetName: []byte("1002000"), OwnerAddress: owner, ToAddress: contract, Amount: 10}),
+		withContract(core.Transaction_Contract_FreezeBalanceV2Contract,
+			&core.FreezeBalanceV2Contract{OwnerAddress: owner, FrozenBalance: 1_000_000}),
+		withContract(core.Transaction_Contract_UnfreezeBalanceV2Contract,
+			&core.UnfreezeBalanceV2Contract{OwnerAddress: owner, UnfreezeBalance: 100_000_000}),
+	} {
+		if err := limit.Check(context.Background(), req); err != nil {
+			t.Fatal(err)
+		}
+		limit.Commit(req)
+	}
+	if limit.SpentOf(owner, token) != 90 || limit.SpentOf(owner, "1002000") != 10 || limit.Spent(owner) != 4_000_000 {
+		t.Fatalf("unexpected spent %d %d %d", limit.SpentOf(owner, token), limit.SpentOf(owner, "1002000"), limit.Spent(owner))
+	}
+	if err := limit.Check(context.Background(), call(contract, calldata(trc20Approve, 2, big.NewInt(11)))); !errors.Is(err, ErrPolicyRejected) {
+		t.Fatalf("expecting TRC20 approval over the limit rejected, got %v", err)
+	}
+	maxUint256 := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
+	for _, req := range []*SignRequest{
+		call(contract, calldata(trc20Approve, 2, maxUint256)),
+		call(contract, calldata(trc20BalanceOf, 1, big.NewInt(0))),
+		call(contract, trc20Transfer),
+		withContract(core.Transaction_Contract_AccountPermissionUpdateContract,
+			&core.AccountPermissionUpdateContract{OwnerAddress: owner}),
+	} {
+		if err := limit.Check(context.Background(), req); !errors.Is(err, ErrBadSignRequest) {
+			t.Fatalf("expecting %v rejected, got %v", req.Raw, err)
+		}
+	}
+}
+
+func TestSignerServer_Owners(t *testing.T) {
+	priv, _ := hex.DecodeString("4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318")
+	key, _ := NewPrivateKeySigner(priv)
+	other, _ := hex.DecodeString(testToHex)
+	server := NewSignerServer([]Signer{key})
+	sign := func(owner []byte) error {
+		param, _ := anypb.New(&core.TransferContract{OwnerAddress: owner, ToAddress: other, Amount: 1})
+		rawData, _ := proto.Marshal(&core.TransactionRaw{Contract: []*core.Transaction_Contract{
+			{Type: core.Transaction_Contract_TransferContract, Parameter: param}}})
+		txid := sha256.Sum256(rawData)
+		_, err := server.Sign(context.Background(), key.Address(), txid[:], rawData, nil)
+		return err
+	}
+	if err := sign(key.Address()); err != nil {
+		t.Fatal(err)
+	}
+	if err := sign(other); !errors.Is(err, ErrPolicyRejected) {
+		t.Fatalf("expecting other owner rejected, got %v", err)
+	}
+	if err := sign(nil); !errors.Is(err, ErrBadSignRequest) {
+		t.Fatalf("expecting empty owner rejected, got %v", err)
+	}
+	server.AllowOwners(key.Address(), other)
+	if err := sign(other); err != nil {
+		t.Fatal(err)
+	}
+}
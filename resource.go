@@ -0,0 +1,145 @@
+package go_tronsdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+)
+
+const (
+	EnergyFeeKey      = "getEnergyFee"      // sun per energy burnt
+	TransactionFeeKey = "getTransactionFee" // sun per bandwidth byte burnt
+
+	SunPerTRX = 1_000_000
+)
+
+// blackHoleAddress is the account queried for the network totals if no account is given
+var blackHoleAddress = address.HexToAddress("410000000000000000000000000000000000000000")
+
+func (c *TronClient) GetAccountResource(cctx context.Context, addr address.Address) (*api.AccountResourceMessage, error) {
+	return _backendRun(cctx, c, "GetAccountResource", func(ctx context.Context, b Backend) (*api.AccountResourceMessage, error) {
+		return b.GetAccountResource(ctx, addr)
+	})
+}
+
+func (c *TronClient) GetChainParameters(cctx context.Context) (*core.ChainParameters, error) {
+	return _backendRun(cctx, c, "GetChainParameters", func(ctx context.Context, b Backend) (*core.ChainParameters, error) {
+		return b.GetChainParameters(ctx)
+	})
+}
+
+func chainParameterOf(params *core.ChainParameters, key string) (int64, error) {
+	for _, cp := range params.GetChainParameter() {
+		if cp != nil && cp.Key == key {
+			return cp.Value, nil
+		}
+	}
+	return 0, fmt.Errorf("chain parameters key:%s not found", key)
+}
+
+// ResourceCalculator converts between staked TRX and the resources recovered every 24 hours, with
+// the network totals at the time it is created. Amounts of TRX are in sun.
+type ResourceCalculator struct {
+	TotalEnergyLimit  int64
+	TotalEnergyWeight int64 // TRX staked for energy by the network
+	TotalNetLimit     int64
+	TotalNetWeight    int64 // TRX staked for bandwidth by the network
+	EnergyFee         int64 // sun per energy burnt
+	TransactionFee    int64 // sun per bandwidth byte burnt
+}
+
+// NewResourceCalculator fetches the network totals with the account resource of addr, which
+// could be any account, and the fees from chain parameters. The black hole account is used if
+// addr is nil.
+func (c *TronClient) NewResourceCalculator(ctx context.Context, addr address.Address) (*ResourceCalculator, error) {
+	if len(addr) == 0 {
+		addr = blackHoleAddress
+	}
+	res, err := c.GetAccountResource(ctx, addr)
+	if err != nil {
+		return nil, fmt.Errorf("account resource: %w", err)
+	}
+	if res.TotalEnergyWeight <= 0 || res.TotalNetWeight <= 0 {
+		return nil, errors.New("network totals not found")
+	}
+	params, err := c.GetChainParameters(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("chain parameters: %w", err)
+	}
+	calc := &ResourceCalculator{
+		TotalEnergyLimit:  res.TotalEnergyLimit,
+		TotalEnergyWeight: res.TotalEnergyWeight,
+		TotalNetLimit:     res.TotalNetLimit,
+		TotalNetWeight:    res.TotalNetWeight,
+	}
+	if calc.EnergyFee, err = chainParameterOf(params, EnergyFeeKey); err != nil {
+		return nil, err
+	}
+	if calc.TransactionFee, err = chainParameterOf(params, TransactionFeeKey); err != nil {
+		return nil, err
+	}
+	return calc, nil
+}
+
+// resourceOf is the same as the fullnode, where the staked sun are truncated to TRX
+func resourceOf(stakedSun, totalLimit, totalWeight int64) int64 {
+	if stakedSun <= 0 || totalWeight <= 0 {
+		return 0
+	}
+	return int64(float64(stakedSun/SunPerTRX) * (float64(totalLimit) / float64(totalWeight)))
+}
+
+// stakeOf is the min sun (in whole TRX) for amount of resource
+func stakeOf(amount, totalLimit, totalWeight int64) int64 {
+	if amount <= 0 {
+		return 0
+	}
+	if totalLimit <= 0 {
+		return -1
+	}
+	trx := int64(float64(amount) * float64(totalWeight) / float64(totalLimit))
+	// fix the rounding of float
+	for trx > 0 && resourceOf((trx-1)*SunPerTRX, totalLimit, totalWeight) >= amount {
+		trx--
+	}
+	for resourceOf(trx*SunPerTRX, totalLimit, totalWeight) < amount {
+		trx++
+	}
+	return trx * SunPerTRX
+}
+
+// EnergyOf returns the energy of staking stakedSun
+func (r *ResourceCalculator) EnergyOf(stakedSun int64) int64 {
+	return resourceOf(stakedSun, r.TotalEnergyLimit, r.TotalEnergyWeight)
+}
+
+// BandwidthOf returns the bandwidth of staking stakedSun, excluding the free bandwidth
+func (r *ResourceCalculator) BandwidthOf(stakedSun int64) int64 {
+	return resourceOf(stakedSun, r.TotalNetLimit, r.TotalNetWeight)
+}
+
+// StakeForEnergy returns the sun to be staked for the energy, or -1 if the network has no
+// energy limit
+func (r *ResourceCalculator) StakeForEnergy(energy int64) int64 {
+	return stakeOf(energy, r.TotalEnergyLimit, r.TotalEnergyWeight)
+}
+
+// StakeForBandwidth returns the sun to be staked for the bandwidth, or -1 if the network has
+// no bandwidth limit
+func (r *ResourceCalculator) StakeForBandwidth(bandwidth int64) int64 {
+	return stakeOf(bandwidth, r.TotalNetLimit, r.TotalNetWeight)
+}
+
+// EnergyBurnCost returns the sun burnt for the energy if not staked
+func (r *ResourceCalculator) EnergyBurnCost(energy int64) int64 {
+	return energy * r.EnergyFee
+}
+
+// BandwidthBurnCost returns the sun burnt for the bandwidth if not staked
+func (r *ResourceCalculator) BandwidthBurnCost(bandwidth int64) int64 {
+	return bandwidth * r.TransactionFee
+}
@@ -0,0 +1,69 @@
+package go_tronsdk
+
+import (
+	"context"
+	"testing"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"google.golang.org/grpc"
+)
+
+type resourceWallet struct {
+	*fakeWallet
+}
+
+func (w *resourceWallet) GetAccountResource(_ context.Context, _ *core.Account, _ ...grpc.CallOption) (*api.AccountResourceMessage, error) {
+	return &api.AccountResourceMessage{
+		TotalEnergyLimit:  90_000_000_000,
+		TotalEnergyWeight: 19_000_000_000,
+		TotalNetLimit:     43_200_000_000,
+		TotalNetWeight:    26_000_000_000,
+	}, nil
+}
+
+func (w *resourceWallet) GetChainParameters(_ context.Context, _ *api.EmptyMessage, _ ...grpc.CallOption) (*core.ChainParameters, error) {
+	return &core.ChainParameters{ChainParameter: []*core.ChainParameters_ChainParameter{
+		{Key: MaintenanceTimeIntervalKey, Value: 21600000},
+		{Key: EnergyFeeKey, Value: 210},
+		{Key: TransactionFeeKey, Value: 1000},
+	}}, nil
+}
+
+func TestResourceCalculator(t *testing.T) {
+	client, err := NewClient(context.Background(), WithBackend("fake", NewGrpcBackend(&resourceWallet{&fakeWallet{name: "fake"}})))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = client.Close()
+	}()
+	calc, err := client.NewResourceCalculator(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e := calc.EnergyOf(1000*SunPerTRX + 999_999); e != 4736 {
+		t.Fatalf("expecting 4736 energy, got %d", e)
+	}
+	if b := calc.BandwidthOf(1000 * SunPerTRX); b != 1661 {
+		t.Fatalf("expecting 1661 bandwidth, got %d", b)
+	}
+	for _, energy := range []int64{1, 4736, 4737, 65_000} {
+		stake := calc.StakeForEnergy(energy)
+		if calc.EnergyOf(stake) < energy || calc.EnergyOf(stake-SunPerTRX) >= energy {
+			t.Fatalf("%d energy: %d sun is not the min stake", energy, stake)
+		}
+	}
+	if s := calc.StakeForEnergy(65_000); s != 13723*SunPerTRX {
+		t.Fatalf("expecting 13723 TRX, got %d", s)
+	}
+	if s := calc.StakeForBandwidth(0); s != 0 {
+		t.Fatalf("expecting 0, got %d", s)
+	}
+	if c := calc.EnergyBurnCost(65_000); c != 13_650_000 {
+		t.Fatalf("expecting 13.65 TRX, got %d", c)
+	}
+	if c := calc.BandwidthBurnCost(345); c != 345_000 {
+		t.Fatalf("expecting 0.345 TRX, got %d", c)
+	}
+}
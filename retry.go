@@ -0,0 +1,243 @@
+package go_tronsdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrorClass tells whether a failed call could be retried
+type ErrorClass int
+
+const (
+	// ClassUnknown leaves the error to the next classifier
+	ClassUnknown ErrorClass = iota
+	// ClassPermanent errors are caused by the request, retrying never helps
+	ClassPermanent
+	// ClassTransient errors are failures of the endpoint, the request may or may not have been
+	// processed, so that only idempotent methods are retried
+	ClassTransient
+	// ClassRateLimited errors are refusals before processing, all methods are retried
+	ClassRateLimited
+)
+
+func (ec ErrorClass) String() string {
+	switch ec {
+	case ClassUnknown:
+		return "Unknown"
+	case ClassPermanent:
+		return "Permanent"
+	case ClassTransient:
+		return "Transient"
+	case ClassRateLimited:
+		return "RateLimited"
+	default:
+		return fmt.Sprintf("ErrorClass(%d)", int(ec))
+	}
+}
+
+// ErrorClassifier classifies err, returns ClassUnknown to pass it on
+type ErrorClassifier func(err error) ErrorClass
+
+// JSON-RPC error code used by most providers for exceeding the rate limit
+const jsonRpcLimitExceeded = -32005
+
+// DefaultClassifier classifies the errors of gRPC, HTTP API and JSON-RPC
+func DefaultClassifier(err error) ErrorClass {
+	if err == nil {
+		return ClassUnknown
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, ErrTransportUnavailable) {
+		return ClassPermanent
+	}
+	var herr HTTPError
+	if errors.As(err, &herr) {
+		return httpStatusClass(herr.StatusCode)
+	}
+	var rherr rpc.HTTPError
+	if errors.As(err, &rherr) {
+		return httpStatusClass(rherr.StatusCode)
+	}
+	var rerr rpc.Error
+	if errors.As(err, &rerr) {
+		if rerr.ErrorCode() == jsonRpcLimitExceeded {
+			return ClassRateLimited
+		}
+		msg := strings.ToLower(rerr.Error())
+		if strings.Contains(msg, "rate limit") || strings.Contains(msg, "too many requests") {
+			return ClassRateLimited
+		}
+		return ClassPermanent
+	}
+	if s, ok := status.FromError(err); ok && s.Code() == codes.ResourceExhausted {
+		return ClassRateLimited
+	}
+	if isTransportError(err) {
+		return ClassTransient
+	}
+	return ClassPermanent
+}
+
+func httpStatusClass(code int) ErrorClass {
+	switch {
+	case code == http.StatusTooManyRequests || code == http.StatusServiceUnavailable:
+		return ClassRateLimited
+	case code >= 500:
+		return ClassTransient
+	default:
+		return ClassPermanent
+	}
+}
+
+// RetryAfter returns the delay asked by the Retry-After header of an HTTP API error
+func RetryAfter(err error) (time.Duration, bool) {
+	var herr HTTPError
+	if !errors.As(err, &herr) || herr.Header == nil {
+		return 0, false
+	}
+	v := herr.Header.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		d := time.Until(t)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+	return 0, false
+}
+
+// methods which change the chain state, retried only if the request was refused
+var nonIdempotentMethods = map[string]bool{
+	"broadcasttransaction": true,
+	"broadcasthex":         true,
+}
+
+// RetryPolicy retries failed calls with exponential backoff and jitter. A nil policy runs
+// each call once.
+type RetryPolicy struct {
+	// MaxAttempts including the first call, no retry if less than 2
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Multiplier     float64
+	// Jitter is the fraction of each backoff to be randomized, in [0, 1]
+	Jitter float64
+	// Classifiers are consulted in order before DefaultClassifier
+	Classifiers []ErrorClassifier
+	// Idempotent overrides whether a method (case-insensitive, e.g. "GetAccount" or
+	// "getaccount") is safe to retry on transient errors. All methods are idempotent except
+	// the broadcasts by default.
+	Idempotent map[string]bool
+}
+
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: 200 * time.Millisecond,
+		MaxBackoff:     5 * time.Second,
+		Multiplier:     2,
+		Jitter:         0.2,
+	}
+}
+
+// Classify err with the Classifiers and then DefaultClassifier
+func (p *RetryPolicy) Classify(err error) ErrorClass {
+	for _, classifier := range p.Classifiers {
+		if ec := classifier(err); ec != ClassUnknown {
+			return ec
+		}
+	}
+	if ec := DefaultClassifier(err); ec != ClassUnknown {
+		return ec
+	}
+	return ClassPermanent
+}
+
+func (p *RetryPolicy) idempotent(method string) bool {
+	method = strings.ToLower(method)
+	for m, idempotent := range p.Idempotent {
+		if strings.ToLower(m) == method {
+			return idempotent
+		}
+	}
+	return !nonIdempotentMethods[method]
+}
+
+func (p *RetryPolicy) shouldRetry(method string, err error) bool {
+	switch p.Classify(err) {
+	case ClassRateLimited:
+		return true
+	case ClassTransient:
+		return p.idempotent(method)
+	default:
+		return false
+	}
+}
+
+// backoff before the retry-th retry (from 1), Retry-After of err takes precedence
+func (p *RetryPolicy) backoff(retry int, err error) time.Duration {
+	if d, ok := RetryAfter(err); ok {
+		if p.MaxBackoff > 0 && d > p.MaxBackoff {
+			d = p.MaxBackoff
+		}
+		return d
+	}
+	d := float64(p.InitialBackoff)
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+	for i := 1; i < retry; i++ {
+		d *= multiplier
+		if p.MaxBackoff > 0 && d > float64(p.MaxBackoff) {
+			break
+		}
+	}
+	if p.MaxBackoff > 0 && d > float64(p.MaxBackoff) {
+		d = float64(p.MaxBackoff)
+	}
+	if p.Jitter > 0 {
+		jitter := p.Jitter
+		if jitter > 1 {
+			jitter = 1
+		}
+		d -= d * jitter * rand.Float64()
+	}
+	return time.Duration(d)
+}
+
+// _retryRun runs f as method, and retries it as the policy allows
+func _retryRun[T any](ctx context.Context, p *RetryPolicy, method string, f func(context.Context) (T, error)) (t T, err error) {
+	if p == nil || p.MaxAttempts < 2 {
+		return f(ctx)
+	}
+	for attempt := 1; ; attempt++ {
+		t, err = f(ctx)
+		if err == nil || attempt >= p.MaxAttempts || ctx.Err() != nil || !p.shouldRetry(method, err) {
+			break
+		}
+		timer := time.NewTimer(p.backoff(attempt, err))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return t, err
+		case <-timer.C:
+		}
+	}
+	return t, err
+}
@@ -0,0 +1,201 @@
+package go_tronsdk
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestDefaultClassifier(t *testing.T) {
+	tests := []struct {
+		err  error
+		want ErrorClass
+	}{
+		{status.Error(codes.Unavailable, "down"), ClassTransient},
+		{status.Error(codes.ResourceExhausted, "quota"), ClassRateLimited},
+		{status.Error(codes.NotFound, "not found"), ClassPermanent},
+		{HTTPError{StatusCode: http.StatusTooManyRequests}, ClassRateLimited},
+		{HTTPError{StatusCode: http.StatusBadGateway}, ClassTransient},
+		{HTTPError{StatusCode: http.StatusBadRequest}, ClassPermanent},
+		{rpc.HTTPError{StatusCode: http.StatusServiceUnavailable}, ClassRateLimited},
+		{context.Canceled, ClassPermanent},
+		{TransportUnavailableError{Transport: TransportJSONRPC}, ClassPermanent},
+		{errors.New("revert"), ClassPermanent},
+	}
+	for _, test := range tests {
+		if got := DefaultClassifier(test.err); got != test.want {
+			t.Errorf("%v: expecting %s, got %s", test.err, test.want, got)
+		}
+	}
+
+	p := &RetryPolicy{Classifiers: []ErrorClassifier{func(err error) ErrorClass {
+		if err.Error() == "revert" {
+			return ClassTransient
+		}
+		return ClassUnknown
+	}}}
+	if got := p.Classify(errors.New("revert")); got != ClassTransient {
+		t.Fatalf("custom classifier should take precedence, got %s", got)
+	}
+	if got := p.Classify(status.Error(codes.ResourceExhausted, "")); got != ClassRateLimited {
+		t.Fatalf("unknown should fall back to the default classifier, got %s", got)
+	}
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	p := &RetryPolicy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second, Multiplier: 2}
+	for retry, want := range map[int]time.Duration{1: 100 * time.Millisecond, 3: 400 * time.Millisecond, 10: time.Second} {
+		if got := p.backoff(retry, nil); got != want {
+			t.Errorf("retry %d: expecting %s, got %s", retry, want, got)
+		}
+	}
+	p.Jitter = 0.5
+	for i := 0; i < 10; i++ {
+		if got := p.backoff(2, nil); got < 100*time.Millisecond || got > 200*time.Millisecond {
+			t.Fatalf("backoff with jitter out of range: %s", got)
+		}
+	}
+	err := HTTPError{StatusCode: http.StatusTooManyRequests, Header: http.Header{"Retry-After": []string{"3"}}}
+	if got := p.backoff(1, err); got != time.Second {
+		t.Fatalf("Retry-After should be capped by MaxBackoff, got %s", got)
+	}
+	p.MaxBackoff = 0
+	if got := p.backoff(1, err); got != 3*time.Second {
+		t.Fatalf("expecting Retry-After 3s, got %s", got)
+	}
+}
+
+func TestRetryRun(t *testing.T) {
+	p := &RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}
+	run := func(method string, errs ...error) (int, error) {
+		calls := 0
+		_, err := _retryRun(context.Background(), p, method, func(context.Context) (int, error) {
+			calls++
+			if calls <= len(errs) {
+				return 0, errs[calls-1]
+			}
+			return calls, nil
+		})
+		return calls, err
+	}
+	unavailable := status.Error(codes.Unavailable, "down")
+	if calls, err := run("GetAccount", unavailable, unavailable); err != nil || calls != 3 {
+		t.Fatalf("expecting success at the 3rd attempt, got %d %v", calls, err)
+	}
+	if calls, err := run("GetAccount", unavailable, unavailable, unavailable); err != unavailable || calls != 3 {
+		t.Fatalf("expecting 3 attempts, got %d %v", calls, err)
+	}
+	if calls, err := run("BroadcastTransaction", unavailable); err != unavailable || calls != 1 {
+		t.Fatalf("broadcast should not be retried on transient errors, got %d %v", calls, err)
+	}
+	limited := HTTPError{StatusCode: http.StatusTooManyRequests}
+	if calls, err := run("broadcasttransaction", limited); err != nil || calls != 2 {
+		t.Fatalf("broadcast should be retried when rate limited, got %d %v", calls, err)
+	}
+	p.Idempotent = map[string]bool{"GetAccount": false}
+	if calls, _ := run("getaccount", unavailable); calls != 1 {
+		t.Fatalf("method marked non-idempotent should not be retried, got %d", calls)
+	}
+}
+
+func TestHttpClient_Retry(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_, _ = w.Write([]byte(`{"account_name":"6f6b"}`))
+	}))
+	defer server.Close()
+
+	client := NewHttpClient(server.URL, 1, WithHttpRetry(DefaultRetryPolicy()))
+	acc, err := client.GetAccount(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(acc.AccountName) != "ok" || calls != 2 {
+		t.Fatalf("expecting retried answer, got %v after %d calls", acc, calls)
+	}
+
+	calls = 0
+	_, err = client.BroadcastTransaction(context.Background(), &core.Transaction{RawData: &core.TransactionRaw{}})
+	if err != nil || calls != 2 {
+		t.Fatalf("rate limited broadcast should be retried, got %v after %d calls", err, calls)
+	}
+}
+
+// scriptedBroadcasts answers the broadcasts of all its wallets in turn, in the order of calls
+type scriptedBroadcasts struct {
+	answers []func() (*api.Return, error)
+	calls   int
+}
+
+type scriptedWallet struct {
+	*fakeWallet
+	script *scriptedBroadcasts
+}
+
+func (w *scriptedWallet) BroadcastTransaction(_ context.Context, _ *core.Transaction, _ ...grpc.CallOption) (*api.Return, error) {
+	s := w.script
+	s.calls++
+	if s.calls > len(s.answers) {
+		return nil, errors.New("unexpected broadcast")
+	}
+	return s.answers[s.calls-1]()
+}
+
+func TestTronClient_BroadcastRetry(t *testing.T) {
+	timeout := func() (*api.Return, error) { return nil, status.Error(codes.DeadlineExceeded, "timeout") }
+	limited := func() (*api.Return, error) { return nil, status.Error(codes.ResourceExhausted, "quota") }
+	busy := func() (*api.Return, error) { return &api.Return{Code: api.Return_SERVER_BUSY}, nil }
+	dup := func() (*api.Return, error) { return &api.Return{Code: api.Return_DUP_TRANSACTION_ERROR}, nil }
+	ok := func() (*api.Return, error) { return &api.Return{Result: true}, nil }
+	tests := []struct {
+		name    string
+		answers []func() (*api.Return, error)
+		success bool
+	}{
+		// A may have accepted it before timing out, B refused, C answers DUP
+		{"timeout-limited-dup", []func() (*api.Return, error){timeout, limited, dup}, true},
+		// all refused before processing, broadcast again
+		{"limited-busy-limited-ok", []func() (*api.Return, error){limited, busy, limited, ok}, true},
+		// all refused in the first attempt, no one has received it, DUP is not trusted
+		{"limited-limited-limited-dup", []func() (*api.Return, error){limited, limited, limited, dup}, false},
+		// A may have accepted it, never broadcast again
+		{"timeout-limited-limited", []func() (*api.Return, error){timeout, limited, limited}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			script := &scriptedBroadcasts{answers: tt.answers}
+			policy := DefaultRetryPolicy()
+			policy.InitialBackoff, policy.Jitter = time.Millisecond, 0
+			client, err := NewClient(context.Background(), WithRetry(policy),
+				WithBackend("a", NewGrpcBackend(&scriptedWallet{&fakeWallet{name: "a"}, script})),
+				WithBackend("b", NewGrpcBackend(&scriptedWallet{&fakeWallet{name: "b"}, script})),
+				WithBackend("c", NewGrpcBackend(&scriptedWallet{&fakeWallet{name: "c"}, script})))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer func() {
+				_ = client.Close()
+			}()
+			err = client.BroadcastTransaction(context.Background(), &core.Transaction{RawData: &core.TransactionRaw{}})
+			if (err == nil) != tt.success || script.calls != len(tt.answers) {
+				t.Fatalf("expecting success:%t after %d calls, got %v after %d calls", tt.success, len(tt.answers), err, script.calls)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package go_tronsdk
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+)
+
+// Signer signs transactions for its address without exposing the private key, so that keys
+// could be kept by another component, such as a keystore or a remote signing service.
+type Signer interface {
+	Address() address.Address
+	// SignTxID returns the 65 bytes recoverable secp256k1 signature ([R || S || V], V is 0 or 1)
+	// of the 32 bytes txid, which is the sha256 of the raw data of the transaction
+	SignTxID(ctx context.Context, txid []byte) ([]byte, error)
+}
+
+// PrivateKeySigner signs with the private key held in memory
+type PrivateKeySigner struct {
+	key  *ecdsa.PrivateKey
+	addr address.Address
+}
+
+func NewPrivateKeySigner(priv []byte) (*PrivateKeySigner, error) {
+	key, err := BytesToPrivateKey(priv)
+	if err != nil {
+		return nil, err
+	}
+	return newPrivateKeySigner(key), nil
+}
+
+func newPrivateKeySigner(key *ecdsa.PrivateKey) *PrivateKeySigner {
+	return &PrivateKeySigner{key: key, addr: address.PubkeyToAddress(key.PublicKey)}
+}
+
+func (s *PrivateKeySigner) Address() address.Address {
+	return s.addr
+}
+
+func (s *PrivateKeySigner) SignTxID(_ context.Context, txid []byte) ([]byte, error) {
+	if len(txid) != 32 {
+		return nil, errors.New("txid should be 32 bytes")
+	}
+	return crypto.Sign(txid, s.key)
+}
+
+// KeystoreSigner signs with an account of go-ethereum keystore. The account must have been
+// unlocked if no passphrase is given.
+type KeystoreSigner struct {
+	ks         *keystore.KeyStore
+	account    accounts.Account
+	passphrase *string
+	addr       address.Address
+}
+
+func NewKeystoreSigner(ks *keystore.KeyStore, account accounts.Account) *KeystoreSigner {
+	addr := append([]byte{address.TronBytePrefix}, account.Address.Bytes()...)
+	return &KeystoreSigner{ks: ks, account: account, addr: addr}
+}
+
+// NewKeystoreSignerWithPassphrase decrypts the key with passphrase for each signing, rather than
+// keeping it unlocked in the keystore
+func NewKeystoreSignerWithPassphrase(ks *keystore.KeyStore, account accounts.Account, passphrase string) *KeystoreSigner {
+	s := NewKeystoreSigner(ks, account)
+	s.passphrase = &passphrase
+	return s
+}
+
+func (s *KeystoreSigner) Address() address.Address {
+	return s.addr
+}
+
+func (s *KeystoreSigner) SignTxID(_ context.Context, txid []byte) ([]byte, error) {
+	if len(txid) != 32 {
+		return nil, errors.New("txid should be 32 bytes")
+	}
+	if s.passphrase != nil {
+		return s.ks.SignHashWithPassphrase(s.account, *s.passphrase, txid)
+	}
+	return s.ks.SignHash(s.account, txid)
+}
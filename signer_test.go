@@ -0,0 +1,53 @@
+package go_tronsdk
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+)
+
+func TestSigners(t *testing.T) {
+	priv, _ := hex.DecodeString("4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318")
+	mem, err := NewPrivateKeySigner(priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, _ := BytesToPrivateKey(priv)
+	ks := keystore.NewKeyStore(t.TempDir(), keystore.LightScryptN, keystore.LightScryptP)
+	account, err := ks.ImportECDSA(key, "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	locked := NewKeystoreSigner(ks, account)
+	withPass := NewKeystoreSignerWithPassphrase(ks, account, "secret")
+
+	txid := sha256.Sum256([]byte("raw data"))
+	if _, err = locked.SignTxID(context.Background(), txid[:]); err == nil {
+		t.Fatal("expecting locked account")
+	}
+	if err = ks.Unlock(account, "secret"); err != nil {
+		t.Fatal(err)
+	}
+	for _, signer := range []Signer{mem, locked, withPass} {
+		if !bytes.Equal(signer.Address(), mem.Address()) {
+			t.Fatalf("%T: expecting address %s, got %s", signer, mem.Address(), signer.Address())
+		}
+		sig, err := signer.SignTxID(context.Background(), txid[:])
+		if err != nil {
+			t.Fatalf("%T: %v", signer, err)
+		}
+		pub, err := crypto.SigToPub(txid[:], sig)
+		if err != nil || !bytes.Equal(address.PubkeyToAddress(*pub), mem.Address()) {
+			t.Fatalf("%T: signature not recovered to the signer, %v", signer, err)
+		}
+		if _, err = signer.SignTxID(context.Background(), txid[:31]); err == nil {
+			t.Fatalf("%T: expecting invalid txid", signer)
+		}
+	}
+}
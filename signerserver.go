@@ -0,0 +1,485 @@
+package go_tronsdk
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+var (
+	ErrPolicyRejected = errors.New("rejected by policy")
+	ErrTxIDMismatch   = errors.New("txid is not the hash of raw data")
+	ErrUnknownSigner  = errors.New("unknown signer")
+	ErrBadSignRequest = errors.New("bad sign request")
+)
+
+// SignRequest is a signing request received by SignerServer, whose raw data has been verified
+// to be hashed to TxID
+type SignRequest struct {
+	Signer address.Address
+	TxID   []byte
+	Raw    *core.TransactionRaw
+	// Peer is the client certificate of the mutual TLS connection, nil if not available
+	Peer *x509.Certificate
+}
+
+// Contracts decodes the contracts of the transaction
+func (r *SignRequest) Contracts() ([]proto.Message, error) {
+	ret := make([]proto.Message, 0, len(r.Raw.GetContract()))
+	for _, c := range r.Raw.GetContract() {
+		if c == nil || c.Parameter == nil {
+			return nil, errors.New("empty contract")
+		}
+		m, err := c.Parameter.UnmarshalNew()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", c.Type, err)
+		}
+		ret = append(ret, m)
+	}
+	return ret, nil
+}
+
+// Values returns the values sent or put at risk by the transaction, by token: TRX in sun by "",
+// TRC10 tokens by their ids, and TRC20 tokens by the base58 addresses of their contracts. The
+// transfer, transferFrom and approve calls of TRC20 are valued by their amounts, and freezing or
+// delegating TRX by the balance. Only transactions of one contract are accepted, and contracts
+// which could not be valued, such as the calls of other functions or permission updates, are
+// rejected.
+func (r *SignRequest) Values() (map[string]int64, error) {
+	contracts, err := r.Contracts()
+	if err != nil {
+		return nil, err
+	}
+	if len(contracts) != 1 {
+		return nil, fmt.Errorf("%w: %d contracts", ErrBadSignRequest, len(contracts))
+	}
+	values := make(map[string]int64)
+	switch v := contracts[0].(type) {
+	case *core.TransferContract:
+		values[""] = v.Amount
+	case *core.TransferAssetContract:
+		values[string(v.AssetName)] = v.Amount
+	case *core.FreezeBalanceV2Contract:
+		values[""] = v.FrozenBalance
+	case *core.DelegateResourceContract:
+		values[""] = v.Balance
+	case *core.UnfreezeBalanceV2Contract, *core.WithdrawExpireUnfreezeContract, *core.CancelAllUnfreezeV2Contract,
+		*core.UnDelegateResourceContract, *core.WithdrawBalanceContract, *core.VoteWitnessContract:
+		// nothing leaves the account
+	case *core.TriggerSmartContract:
+		values[""] = v.CallValue
+		if v.CallTokenValue != 0 {
+			values[strconv.FormatInt(v.TokenId, 10)] = v.CallTokenValue
+		}
+		amount, err := trc20Amount(v.Data)
+		if err != nil {
+			return nil, err
+		}
+		if amount != 0 {
+			values[address.Address(v.ContractAddress).String()] = amount
+		}
+	default:
+		return nil, fmt.Errorf("%w: %s could not be valued", ErrBadSignRequest, r.Raw.Contract[0].GetType())
+	}
+	for token, value := range values {
+		if value < 0 {
+			return nil, fmt.Errorf("%w: negative value %d of %q", ErrBadSignRequest, value, token)
+		}
+	}
+	return values, nil
+}
+
+// trc20Amount decodes the amount of the TRC20 transfer, transferFrom or approve call. A call
+// without data, such as a TRX transfer to a contract, is valued as 0.
+func trc20Amount(data []byte) (int64, error) {
+	if len(data) == 0 {
+		return 0, nil
+	}
+	var offset int
+	switch {
+	case len(data) < 4:
+		return 0, fmt.Errorf("%w: invalid call data %x", ErrBadSignRequest, data)
+	case bytes.Equal(data[:4], trc20Transfer), bytes.Equal(data[:4], trc20Approve):
+		offset = 4 + 32
+	case bytes.Equal(data[:4], trc20TransferFrom):
+		offset = 4 + 64
+	default:
+		return 0, fmt.Errorf("%w: call of %x could not be valued", ErrBadSignRequest, data[:4])
+	}
+	if len(data) < offset+32 {
+		return 0, fmt.Errorf("%w: invalid call data %x", ErrBadSignRequest, data)
+	}
+	amount := new(big.Int).SetBytes(data[offset : offset+32])
+	if !amount.IsInt64() {
+		return 0, fmt.Errorf("%w: TRC20 amount %s out of range", ErrBadSignRequest, amount)
+	}
+	return amount.Int64(), nil
+}
+
+// Owners returns the owner_address of the contracts
+func (r *SignRequest) Owners() ([]address.Address, error) {
+	contracts, err := r.Contracts()
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]address.Address, 0, len(contracts))
+	for _, c := range contracts {
+		m := c.ProtoReflect()
+		fd := m.Descriptor().Fields().ByName("owner_address")
+		if fd == nil || fd.Kind() != protoreflect.BytesKind || len(m.Get(fd).Bytes()) == 0 {
+			return nil, fmt.Errorf("%w: no owner_address in %s", ErrBadSignRequest, m.Descriptor().Name())
+		}
+		ret = append(ret, m.Get(fd).Bytes())
+	}
+	return ret, nil
+}
+
+// addValue returns a+b of non-negative values, false if it overflows
+func addValue(a, b int64) (int64, bool) {
+	if a > math.MaxInt64-b {
+		return 0, false
+	}
+	return a + b, true
+}
+
+// SignPolicy approves the signing requests of SignerServer, a request is signed only if it is
+// approved by all policies
+type SignPolicy interface {
+	Check(ctx context.Context, req *SignRequest) error
+}
+
+// SignCommitter is a SignPolicy which records the requests signed, such as DailyValueLimit
+type SignCommitter interface {
+	SignPolicy
+	Commit(req *SignRequest)
+}
+
+type SignPolicyFunc func(ctx context.Context, req *SignRequest) error
+
+func (f SignPolicyFunc) Check(ctx context.Context, req *SignRequest) error {
+	return f(ctx, req)
+}
+
+// AllowContractTypes only signs the transactions of the types, such as TriggerSmartContract
+func AllowContractTypes(types ...core.Transaction_Contract_ContractType) SignPolicy {
+	allowed := make(map[core.Transaction_Contract_ContractType]bool, len(types))
+	for _, t := range types {
+		allowed[t] = true
+	}
+	return SignPolicyFunc(func(_ context.Context, req *SignRequest) error {
+		for _, c := range req.Raw.GetContract() {
+			if !allowed[c.GetType()] {
+				return fmt.Errorf("%w: contract type %s", ErrPolicyRejected, c.GetType())
+			}
+		}
+		return nil
+	})
+}
+
+func triggersOf(req *SignRequest) ([]*core.TriggerSmartContract, error) {
+	contracts, err := req.Contracts()
+	if err != nil {
+		return nil, err
+	}
+	var ret []*core.TriggerSmartContract
+	for _, c := range contracts {
+		if tsc, ok := c.(*core.TriggerSmartContract); ok {
+			ret = append(ret, tsc)
+		}
+	}
+	return ret, nil
+}
+
+// AllowContracts only signs the calls to the smart contracts, other types of transactions are
+// not checked
+func AllowContracts(contracts ...address.Address) SignPolicy {
+	allowed := make(map[string]bool, len(contracts))
+	for _, c := range contracts {
+		allowed[c.String()] = true
+	}
+	return SignPolicyFunc(func(_ context.Context, req *SignRequest) error {
+		triggers, err := triggersOf(req)
+		if err != nil {
+			return err
+		}
+		for _, tsc := range triggers {
+			if !allowed[address.Address(tsc.ContractAddress).String()] {
+				return fmt.Errorf("%w: contract %s", ErrPolicyRejected, address.Address(tsc.ContractAddress))
+			}
+		}
+		return nil
+	})
+}
+
+// AllowSelectors only signs the calls of the functions by their 4 bytes selectors, such as
+// a9059cbb of transfer(address,uint256). Other types of transactions are not checked.
+func AllowSelectors(selectors ...[]byte) SignPolicy {
+	allowed := make(map[string]bool, len(selectors))
+	for _, s := range selectors {
+		allowed[hex.EncodeToString(s)] = true
+	}
+	return SignPolicyFunc(func(_ context.Context, req *SignRequest) error {
+		triggers, err := triggersOf(req)
+		if err != nil {
+			return err
+		}
+		for _, tsc := range triggers {
+			if len(tsc.Data) < 4 || !allowed[hex.EncodeToString(tsc.Data[:4])] {
+				return fmt.Errorf("%w: selector of %x", ErrPolicyRejected, tsc.Data)
+			}
+		}
+		return nil
+	})
+}
+
+// DailyValueLimit limits the values sent by each signer in a UTC day, see SignRequest.Values.
+// Requests of the tokens without limits are rejected.
+type DailyValueLimit struct {
+	lock   sync.Mutex
+	limits map[string]int64 // by token
+	day    string
+	spent  map[string]map[string]int64 // by signer and token
+	now    func() time.Time
+}
+
+// NewDailyValueLimit limits the TRX sent, see WithToken for the limits of other tokens
+func NewDailyValueLimit(limitSun int64) *DailyValueLimit {
+	return &DailyValueLimit{limits: map[string]int64{"": limitSun}, spent: make(map[string]map[string]int64), now: time.Now}
+}
+
+// WithToken sets the daily limit of the TRC10 token id or the base58 address of the TRC20 contract
+func (l *DailyValueLimit) WithToken(token string, limit int64) *DailyValueLimit {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.limits[token] = limit
+	return l
+}
+
+// reset clears the spent of the previous days
+func (l *DailyValueLimit) reset() {
+	day := l.now().UTC().Format(time.DateOnly)
+	if day != l.day {
+		l.day = day
+		l.spent = make(map[string]map[string]int64)
+	}
+}
+
+func (l *DailyValueLimit) Check(_ context.Context, req *SignRequest) error {
+	values, err := req.Values()
+	if err != nil {
+		return err
+	}
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.reset()
+	for token, value := range values {
+		limit, ok := l.limits[token]
+		if !ok {
+			if value == 0 {
+				continue
+			}
+			return fmt.Errorf("%w: no daily limit of %q", ErrPolicyRejected, token)
+		}
+		spent := l.spent[req.Signer.String()][token]
+		if total, ok := addValue(spent, value); !ok || total > limit {
+			return fmt.Errorf("%w: %d of %q exceeds the daily limit, %d spent", ErrPolicyRejected, value, token, spent)
+		}
+	}
+	return nil
+}
+
+func (l *DailyValueLimit) Commit(req *SignRequest) {
+	values, err := req.Values()
+	if err != nil {
+		return
+	}
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.reset()
+	spent := l.spent[req.Signer.String()]
+	if spent == nil {
+		spent = make(map[string]int64)
+		l.spent[req.Signer.String()] = spent
+	}
+	for token, value := range values {
+		total, ok := addValue(spent[token], value)
+		if !ok {
+			total = math.MaxInt64
+		}
+		spent[token] = total
+	}
+}
+
+// Spent returns the TRX sent by signer today
+func (l *DailyValueLimit) Spent(signer address.Address) int64 {
+	return l.SpentOf(signer, "")
+}
+
+// SpentOf returns the token sent by signer today, see WithToken
+func (l *DailyValueLimit) SpentOf(signer address.Address, token string) int64 {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.reset()
+	return l.spent[signer.String()][token]
+}
+
+// SignerServer is a reference signing service for RemoteSigner, which should be served over
+// mutual TLS (see LoadMTLSConfig). It signs a request only if the raw data is hashed to the
+// txid, so that a compromised caller could not get an arbitrary hash signed, and the policies
+// approve the raw data. The owners of the contracts must be the signer, or the accounts allowed
+// by AllowOwners. Requests are signed one by one.
+type SignerServer struct {
+	lock     sync.Mutex
+	signers  map[string]Signer          // by base58 address
+	owners   map[string]map[string]bool // accounts signed for by the signer, by base58 address
+	policies []SignPolicy
+}
+
+func NewSignerServer(signers []Signer, policies ...SignPolicy) *SignerServer {
+	s := &SignerServer{signers: make(map[string]Signer, len(signers)), owners: make(map[string]map[string]bool),
+		policies: policies}
+	for _, signer := range signers {
+		s.signers[signer.Address().String()] = signer
+	}
+	return s
+}
+
+// AllowOwners allows signer to sign the transactions of the owners, such as the multi-signature
+// accounts which signer holds a permission for
+func (s *SignerServer) AllowOwners(signer address.Address, owners ...address.Address) *SignerServer {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	allowed := s.owners[signer.String()]
+	if allowed == nil {
+		allowed = make(map[string]bool, len(owners))
+		s.owners[signer.String()] = allowed
+	}
+	for _, owner := range owners {
+		allowed[owner.String()] = true
+	}
+	return s
+}
+
+// checkOwners checks that the owners of the contracts are the signer or allowed by AllowOwners
+func (s *SignerServer) checkOwners(req *SignRequest) error {
+	owners, err := req.Owners()
+	if err != nil {
+		return err
+	}
+	for _, owner := range owners {
+		if !bytes.Equal(owner, req.Signer) && !s.owners[req.Signer.String()][owner.String()] {
+			return fmt.Errorf("%w: %s could not sign for %s", ErrPolicyRejected, req.Signer, owner)
+		}
+	}
+	return nil
+}
+
+// Sign verifies and signs the request
+func (s *SignerServer) Sign(ctx context.Context, signer address.Address, txid, rawData []byte, peer *x509.Certificate) ([]byte, error) {
+	h := sha256.Sum256(rawData)
+	if len(txid) != len(h) || !bytes.Equal(h[:], txid) {
+		return nil, ErrTxIDMismatch
+	}
+	raw := new(core.TransactionRaw)
+	if err := proto.Unmarshal(rawData, raw); err != nil {
+		return nil, fmt.Errorf("%w: invalid raw data, %v", ErrBadSignRequest, err)
+	}
+	if len(raw.Contract) == 0 {
+		return nil, fmt.Errorf("%w: no contract in raw data", ErrBadSignRequest)
+	}
+	sg, ok := s.signers[signer.String()]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownSigner, signer)
+	}
+	req := &SignRequest{Signer: signer, TxID: txid, Raw: raw, Peer: peer}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if err := s.checkOwners(req); err != nil {
+		return nil, err
+	}
+	for _, p := range s.policies {
+		if err := p.Check(ctx, req); err != nil {
+			if !errors.Is(err, ErrPolicyRejected) {
+				err = fmt.Errorf("%w: %v", ErrPolicyRejected, err)
+			}
+			return nil, err
+		}
+	}
+	sig, err := signTx(ctx, sg, txid, raw)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range s.policies {
+		if c, ok := p.(SignCommitter); ok {
+			c.Commit(req)
+		}
+	}
+	return sig, nil
+}
+
+func (s *SignerServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	reply := func(status int, ret *remoteSignResponse) {
+		w.Header().Set("content-type", JsonContentType)
+		w.WriteHeader(status)
+		_ = json.NewEncoder(w).Encode(ret)
+	}
+	if r.Method != http.MethodPost {
+		reply(http.StatusMethodNotAllowed, &remoteSignResponse{Error: "POST only"})
+		return
+	}
+	req := new(remoteSignRequest)
+	if err := json.NewDecoder(io.LimitReader(r.Body, 1<<20)).Decode(req); err != nil {
+		reply(http.StatusBadRequest, &remoteSignResponse{Error: err.Error()})
+		return
+	}
+	signer, err := address.Base58ToAddress(req.Address)
+	if err != nil {
+		reply(http.StatusBadRequest, &remoteSignResponse{Error: "invalid address"})
+		return
+	}
+	txid, err := hex.DecodeString(req.TxID)
+	if err != nil {
+		reply(http.StatusBadRequest, &remoteSignResponse{Error: "invalid txid"})
+		return
+	}
+	rawData, err := hex.DecodeString(req.RawDataHex)
+	if err != nil {
+		reply(http.StatusBadRequest, &remoteSignResponse{Error: "invalid raw data"})
+		return
+	}
+	var peer *x509.Certificate
+	if r.TLS != nil && len(r.TLS.PeerCertificates) > 0 {
+		peer = r.TLS.PeerCertificates[0]
+	}
+	sig, err := s.Sign(r.Context(), signer, txid, rawData, peer)
+	switch {
+	case err == nil:
+		reply(http.StatusOK, &remoteSignResponse{Signature: hex.EncodeToString(sig)})
+	case errors.Is(err, ErrPolicyRejected):
+		reply(http.StatusForbidden, &remoteSignResponse{Error: err.Error()})
+	case errors.Is(err, ErrUnknownSigner):
+		reply(http.StatusNotFound, &remoteSignResponse{Error: err.Error()})
+	case errors.Is(err, ErrTxIDMismatch), errors.Is(err, ErrBadSignRequest):
+		reply(http.StatusBadRequest, &remoteSignResponse{Error: err.Error()})
+	default:
+		reply(http.StatusInternalServerError, &remoteSignResponse{Error: err.Error()})
+	}
+}
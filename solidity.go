@@ -0,0 +1,52 @@
+package go_tronsdk
+
+import (
+	"context"
+)
+
+// Consistency selects the data a query is answered with
+type Consistency int
+
+const (
+	// Latest data of fullnodes, which may be rolled back
+	Latest Consistency = iota
+	// Confirmed data of solidity nodes, which has been solidified (irreversible)
+	Confirmed
+)
+
+func (cs Consistency) String() string {
+	switch cs {
+	case Latest:
+		return "Latest"
+	case Confirmed:
+		return "Confirmed"
+	default:
+		return "Consistency(unknown)"
+	}
+}
+
+func consistencyOf(cons []Consistency) Consistency {
+	if len(cons) > 0 {
+		return cons[0]
+	}
+	return Latest
+}
+
+// _consistentRun runs idempotent query f on the fullnodes for Latest (default), or on the
+// solidity nodes for Confirmed.
+func _consistentRun[T any](ctx context.Context, c *TronClient, method string, cons []Consistency,
+	f func(context.Context, ReadBackend) (T, error)) (t T, err error) {
+	if consistencyOf(cons) != Confirmed {
+		return _backendRun(ctx, c, method, func(cctx context.Context, b Backend) (T, error) {
+			return f(cctx, b)
+		})
+	}
+	if c.solidity == nil {
+		return t, TransportUnavailableError{Transport: TransportSolidity}
+	}
+	return _retryRun(ctx, c.retry, method, func(rctx context.Context) (T, error) {
+		return _poolRun(rctx, c.solidity, method, c.timeout, true, func(cctx context.Context, b Backend) (T, error) {
+			return f(cctx, b)
+		})
+	})
+}
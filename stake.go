@@ -0,0 +1,153 @@
+package go_tronsdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+)
+
+// MaxUnfreezingV2 is the max number of pending unfreezings of an account
+const MaxUnfreezingV2 = 32
+
+// Unfreezing is a pending unstake of Stake 2.0, which could be withdrawn after UnlockTime
+type Unfreezing struct {
+	Resource   core.ResourceCode
+	Amount     int64 // sun
+	UnlockTime time.Time
+}
+
+func (u *Unfreezing) String() string {
+	return fmt.Sprintf("{%s %d unlock:%s}", u.Resource, u.Amount, u.UnlockTime.Format(time.RFC3339))
+}
+
+// StakeInfo is the Stake 2.0 state of an account, amounts are in sun
+type StakeInfo struct {
+	// Staked by the account itself and not delegated, by resource
+	Staked map[core.ResourceCode]int64
+	// Delegated to other accounts, by resource
+	Delegated map[core.ResourceCode]int64
+	// Acquired from the delegation of other accounts, by resource
+	Acquired map[core.ResourceCode]int64
+	// Unfreezing is the pending unstakes in the order of unlock time, including the withdrawable ones
+	Unfreezing []*Unfreezing
+	// Withdrawable is the sum of the unfreezings which have been unlocked
+	Withdrawable int64
+}
+
+// TotalStaked returns the staked amount of resource, including the delegated part
+func (s *StakeInfo) TotalStaked(resource core.ResourceCode) int64 {
+	return s.Staked[resource] + s.Delegated[resource]
+}
+
+// ParseStakeInfo parses the Stake 2.0 state of acc, unfreezings unlocked by now are withdrawable
+func ParseStakeInfo(acc *core.Account, now time.Time) *StakeInfo {
+	info := &StakeInfo{
+		Staked:    make(map[core.ResourceCode]int64),
+		Delegated: make(map[core.ResourceCode]int64),
+		Acquired:  make(map[core.ResourceCode]int64),
+	}
+	if acc == nil {
+		return info
+	}
+	for _, f := range acc.FrozenV2 {
+		if f != nil && f.Amount > 0 {
+			info.Staked[f.Type] += f.Amount
+		}
+	}
+	for _, u := range acc.UnfrozenV2 {
+		if u == nil {
+			continue
+		}
+		uf := &Unfreezing{Resource: u.Type, Amount: u.UnfreezeAmount, UnlockTime: time.UnixMilli(u.UnfreezeExpireTime)}
+		info.Unfreezing = append(info.Unfreezing, uf)
+		if !uf.UnlockTime.After(now) {
+			info.Withdrawable += uf.Amount
+		}
+	}
+	sort.SliceStable(info.Unfreezing, func(i, j int) bool {
+		return info.Unfreezing[i].UnlockTime.Before(info.Unfreezing[j].UnlockTime)
+	})
+	if acc.DelegatedFrozenV2BalanceForBandwidth > 0 {
+		info.Delegated[core.ResourceCode_BANDWIDTH] = acc.DelegatedFrozenV2BalanceForBandwidth
+	}
+	if acc.AcquiredDelegatedFrozenV2BalanceForBandwidth > 0 {
+		info.Acquired[core.ResourceCode_BANDWIDTH] = acc.AcquiredDelegatedFrozenV2BalanceForBandwidth
+	}
+	if res := acc.AccountResource; res != nil {
+		if res.DelegatedFrozenV2BalanceForEnergy > 0 {
+			info.Delegated[core.ResourceCode_ENERGY] = res.DelegatedFrozenV2BalanceForEnergy
+		}
+		if res.AcquiredDelegatedFrozenV2BalanceForEnergy > 0 {
+			info.Acquired[core.ResourceCode_ENERGY] = res.AcquiredDelegatedFrozenV2BalanceForEnergy
+		}
+	}
+	return info
+}
+
+// GetStakeInfo gets the Stake 2.0 state of the account
+func (c *TronClient) GetStakeInfo(ctx context.Context, addr address.Address, cons ...Consistency) (*StakeInfo, error) {
+	acc, err := c.GetAccount(ctx, addr, cons...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseStakeInfo(acc, time.Now()), nil
+}
+
+func checkStakeResource(resource core.ResourceCode) error {
+	switch resource {
+	case core.ResourceCode_BANDWIDTH, core.ResourceCode_ENERGY, core.ResourceCode_TRON_POWER:
+		return nil
+	default:
+		return fmt.Errorf("invalid resource %s", resource)
+	}
+}
+
+// FreezeBalanceV2 stakes amountSun of the signer for resource
+func (c *TronClient) FreezeBalanceV2(ctx context.Context, signer Signer, amountSun int64, resource core.ResourceCode,
+	opts *TxOptions) (*api.TransactionExtention, error) {
+	if amountSun <= 0 {
+		return nil, errors.New("amount should be positive")
+	}
+	if err := checkStakeResource(resource); err != nil {
+		return nil, err
+	}
+	return c.sendContract(ctx, signer, core.Transaction_Contract_FreezeBalanceV2Contract, &core.FreezeBalanceV2Contract{
+		FrozenBalance: amountSun,
+		Resource:      resource,
+	}, opts)
+}
+
+// UnfreezeBalanceV2 unstakes amountSun of resource, which could be withdrawn after the unfreezing
+// period of the chain (14 days on mainnet). At most MaxUnfreezingV2 unfreezings could be pending.
+func (c *TronClient) UnfreezeBalanceV2(ctx context.Context, signer Signer, amountSun int64, resource core.ResourceCode,
+	opts *TxOptions) (*api.TransactionExtention, error) {
+	if amountSun <= 0 {
+		return nil, errors.New("amount should be positive")
+	}
+	if err := checkStakeResource(resource); err != nil {
+		return nil, err
+	}
+	return c.sendContract(ctx, signer, core.Transaction_Contract_UnfreezeBalanceV2Contract, &core.UnfreezeBalanceV2Contract{
+		UnfreezeBalance: amountSun,
+		Resource:        resource,
+	}, opts)
+}
+
+// WithdrawExpireUnfreeze withdraws all the unlocked unfreezings to the balance of the signer
+func (c *TronClient) WithdrawExpireUnfreeze(ctx context.Context, signer Signer, opts *TxOptions) (*api.TransactionExtention, error) {
+	return c.sendContract(ctx, signer, core.Transaction_Contract_WithdrawExpireUnfreezeContract,
+		&core.WithdrawExpireUnfreezeContract{}, opts)
+}
+
+// CancelAllUnfreezeV2 cancels all pending unfreezings, the locked ones are staked again and the
+// unlocked ones are withdrawn
+func (c *TronClient) CancelAllUnfreezeV2(ctx context.Context, signer Signer, opts *TxOptions) (*api.TransactionExtention, error) {
+	return c.sendContract(ctx, signer, core.Transaction_Contract_CancelAllUnfreezeV2Contract,
+		&core.CancelAllUnfreezeV2Contract{}, opts)
+}
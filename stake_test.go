@@ -0,0 +1,80 @@
+package go_tronsdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+)
+
+func TestParseStakeInfo(t *testing.T) {
+	now := time.UnixMilli(1700000000000)
+	acc := &core.Account{
+		FrozenV2: []*core.Account_FreezeV2{
+			{Type: core.ResourceCode_BANDWIDTH, Amount: 100},
+			{Type: core.ResourceCode_ENERGY, Amount: 200},
+			{Type: core.ResourceCode_TRON_POWER},
+		},
+		UnfrozenV2: []*core.Account_UnFreezeV2{
+			{Type: core.ResourceCode_ENERGY, UnfreezeAmount: 30, UnfreezeExpireTime: now.Add(time.Hour).UnixMilli()},
+			{Type: core.ResourceCode_BANDWIDTH, UnfreezeAmount: 10, UnfreezeExpireTime: now.Add(-time.Hour).UnixMilli()},
+			{Type: core.ResourceCode_ENERGY, UnfreezeAmount: 20, UnfreezeExpireTime: now.UnixMilli()},
+		},
+		DelegatedFrozenV2BalanceForBandwidth: 50,
+		AccountResource: &core.Account_AccountResource{
+			DelegatedFrozenV2BalanceForEnergy:         70,
+			AcquiredDelegatedFrozenV2BalanceForEnergy: 5,
+		},
+	}
+	info := ParseStakeInfo(acc, now)
+	if info.Staked[core.ResourceCode_BANDWIDTH] != 100 || info.Staked[core.ResourceCode_ENERGY] != 200 || len(info.Staked) != 2 {
+		t.Fatalf("unexpected staked %v", info.Staked)
+	}
+	if info.TotalStaked(core.ResourceCode_ENERGY) != 270 || info.Acquired[core.ResourceCode_ENERGY] != 5 {
+		t.Fatalf("unexpected delegated %v acquired %v", info.Delegated, info.Acquired)
+	}
+	if info.Withdrawable != 30 || len(info.Unfreezing) != 3 || info.Unfreezing[0].Amount != 10 || info.Unfreezing[2].Amount != 30 {
+		t.Fatalf("unexpected unfreezing %v withdrawable %d", info.Unfreezing, info.Withdrawable)
+	}
+}
+
+func TestTronClient_FreezeBalanceV2(t *testing.T) {
+	w := &fakeWallet{name: "fake", height: 100}
+	client, err := NewClient(context.Background(), WithBackend("fake", NewGrpcBackend(w)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = client.Close()
+	}()
+	priv, _ := hex.DecodeString("4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318")
+	signer, _ := NewPrivateKeySigner(priv)
+
+	txx, err := client.FreezeBalanceV2(context.Background(), signer, 1_000_000, core.ResourceCode_ENERGY, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fc := new(core.FreezeBalanceV2Contract)
+	if err = txx.Transaction.RawData.Contract[0].Parameter.UnmarshalTo(fc); err != nil {
+		t.Fatal(err)
+	}
+	if fc.FrozenBalance != 1_000_000 || fc.Resource != core.ResourceCode_ENERGY || !bytes.Equal(fc.OwnerAddress, signer.Address()) {
+		t.Fatalf("unexpected contract %v", fc)
+	}
+	if _, err = client.WithdrawExpireUnfreeze(context.Background(), nil, nil); err == nil {
+		t.Fatal("expecting nil signer")
+	}
+	if _, err = client.UnfreezeBalanceV2(context.Background(), signer, 1, core.ResourceCode(9), nil); err == nil {
+		t.Fatal("expecting invalid resource")
+	}
+	if txx, err = client.CancelAllUnfreezeV2(context.Background(), signer, nil); err != nil ||
+		txx.Transaction.RawData.Contract[0].Type != core.Transaction_Contract_CancelAllUnfreezeV2Contract {
+		t.Fatalf("cancel all unfreeze failed: %v", err)
+	}
+	if len(w.broadcasts) != 2 {
+		t.Fatalf("expecting 2 broadcasts, got %d", len(w.broadcasts))
+	}
+}
@@ -0,0 +1,217 @@
+package go_tronsdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+)
+
+// TIP712ChainIDMask masks the chainId of the domain, which is the last 4 bytes of the genesis
+// block hash in TRON (block.chainid of TVM), such as 0x2b6653dc of the mainnet.
+const TIP712ChainIDMask = 0xffffffff
+
+// TIP-712 (https://github.com/tronprotocol/tips/blob/master/tip-712.md) is EIP-712 with:
+//   - address values are TRON addresses (base58, or hex with 41 prefix), encoded as the 20 bytes
+//     address without the prefix
+//   - trcToken is an atomic type encoded as uint256, and remains trcToken in the type hash
+//   - chainId of the domain is masked by TIP712ChainIDMask
+//
+// The typed data is apitypes.TypedData of go-ethereum, the same json as TronWeb
+// trx._signTypedData.
+
+// tip712Address returns the 20 bytes address of v, which could be a TRON address (string of
+// base58 or hex, address.Address, 21 bytes) or an ethereum address (0x hex, 20 bytes).
+func tip712Address(v interface{}) ([]byte, error) {
+	switch val := v.(type) {
+	case string:
+		if strings.HasPrefix(val, "T") {
+			addr, err := address.Base58ToAddress(val)
+			if err != nil {
+				return nil, err
+			}
+			return addr[1:], nil
+		}
+		s := strings.TrimPrefix(strings.TrimPrefix(val, "0x"), "0X")
+		b, err := hex.DecodeString(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid address %s: %w", val, err)
+		}
+		return tip712Address(b)
+	case address.Address:
+		return tip712Address([]byte(val))
+	case common.Address:
+		return val.Bytes(), nil
+	case []byte:
+		if len(val) == 20 {
+			return val, nil
+		}
+		if len(val) == 21 && val[0] == address.TronBytePrefix {
+			return val[1:], nil
+		}
+	}
+	return nil, fmt.Errorf("invalid address %v", v)
+}
+
+func tip712EncodePrimitive(td *apitypes.TypedData, encType string, encValue interface{}, depth int) ([]byte, error) {
+	switch encType {
+	case "address":
+		addr, err := tip712Address(encValue)
+		if err != nil {
+			return nil, err
+		}
+		return common.LeftPadBytes(addr, 32), nil
+	case "trcToken":
+		return td.EncodePrimitiveValue("uint256", encValue, depth)
+	default:
+		return td.EncodePrimitiveValue(encType, encValue, depth)
+	}
+}
+
+func tip712Slice(v interface{}) ([]interface{}, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return nil, fmt.Errorf("provided data '%v' is not slice", v)
+	}
+	ret := make([]interface{}, rv.Len())
+	for i := range ret {
+		ret[i] = rv.Index(i).Interface()
+	}
+	return ret, nil
+}
+
+// tip712EncodeData is apitypes.TypedData.EncodeData with the TIP-712 primitive types
+func tip712EncodeData(td *apitypes.TypedData, primaryType string, data map[string]interface{}, depth int) ([]byte, error) {
+	fields, ok := td.Types[primaryType]
+	if !ok {
+		return nil, fmt.Errorf("type %s not defined", primaryType)
+	}
+	if len(data) > len(fields) {
+		return nil, fmt.Errorf("there is extra data provided in the message (%d < %d)", len(fields), len(data))
+	}
+	var buf bytes.Buffer
+	buf.Write(td.TypeHash(primaryType))
+	for _, field := range fields {
+		encoded, err := tip712EncodeValue(td, field.Type, data[field.Name], depth)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", field.Name, err)
+		}
+		buf.Write(encoded)
+	}
+	return buf.Bytes(), nil
+}
+
+// tip712EncodeValue returns the 32 bytes encoding of value. An array is the keccak256 of the
+// encodings of its items, whose type is encType without the last dimension, so that T[][] is
+// an array of T[].
+func tip712EncodeValue(td *apitypes.TypedData, encType string, value interface{}, depth int) ([]byte, error) {
+	if strings.HasSuffix(encType, "]") {
+		items, err := tip712Slice(value)
+		if err != nil {
+			return nil, err
+		}
+		itemType := encType[:strings.LastIndex(encType, "[")]
+		var buf bytes.Buffer
+		for _, item := range items {
+			encoded, err := tip712EncodeValue(td, itemType, item, depth)
+			if err != nil {
+				return nil, err
+			}
+			buf.Write(encoded)
+		}
+		return crypto.Keccak256(buf.Bytes()), nil
+	}
+	if _, ok := td.Types[encType]; !ok {
+		return tip712EncodePrimitive(td, encType, value, depth)
+	}
+	m, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("provided data '%v' doesn't match type '%s'", value, encType)
+	}
+	encoded, err := tip712EncodeData(td, encType, m, depth+1)
+	if err != nil {
+		return nil, err
+	}
+	return crypto.Keccak256(encoded), nil
+}
+
+// TIP712HashStruct returns the hashStruct of data of primaryType in typedData
+func TIP712HashStruct(typedData *apitypes.TypedData, primaryType string, data map[string]interface{}) ([]byte, error) {
+	encoded, err := tip712EncodeData(typedData, primaryType, data, 1)
+	if err != nil {
+		return nil, err
+	}
+	return crypto.Keccak256(encoded), nil
+}
+
+// TIP712DomainSeparator returns the hashStruct of the domain of typedData
+func TIP712DomainSeparator(typedData *apitypes.TypedData) ([]byte, error) {
+	domain := typedData.Domain.Map()
+	if len(domain) == 0 {
+		return nil, errors.New("domain is undefined")
+	}
+	if typedData.Domain.ChainId != nil {
+		chainID := new(big.Int).And((*big.Int)(typedData.Domain.ChainId), big.NewInt(TIP712ChainIDMask))
+		domain["chainId"] = (*math.HexOrDecimal256)(chainID)
+	}
+	return TIP712HashStruct(typedData, "EIP712Domain", domain)
+}
+
+// TIP712Hash returns the hash signed for typedData:
+// keccak256("\x19\x01" || domainSeparator || hashStruct(message))
+func TIP712Hash(typedData *apitypes.TypedData) ([]byte, error) {
+	if typedData == nil {
+		return nil, errors.New("nil typed data")
+	}
+	domainSeparator, err := TIP712DomainSeparator(typedData)
+	if err != nil {
+		return nil, fmt.Errorf("domain: %w", err)
+	}
+	structHash, err := TIP712HashStruct(typedData, typedData.PrimaryType, typedData.Message)
+	if err != nil {
+		return nil, fmt.Errorf("message: %w", err)
+	}
+	return crypto.Keccak256([]byte{0x19, 0x01}, domainSeparator, structHash), nil
+}
+
+// SignTypedData signs typedData by signer, and returns the 65 bytes signature [R || S || V]
+// where V is 27 or 28, the same as TronWeb trx._signTypedData.
+func SignTypedData(ctx context.Context, signer Signer, typedData *apitypes.TypedData) ([]byte, error) {
+	if signer == nil {
+		return nil, errors.New("nil signer")
+	}
+	hash, err := TIP712Hash(typedData)
+	if err != nil {
+		return nil, err
+	}
+	return signHash(ctx, signer, hash)
+}
+
+// RecoverTypedDataSigner returns the address which signed typedData, V of sig could be 0, 1,
+// 27 or 28.
+func RecoverTypedDataSigner(typedData *apitypes.TypedData, sig []byte) (address.Address, error) {
+	hash, err := TIP712Hash(typedData)
+	if err != nil {
+		return nil, err
+	}
+	return recoverHashSigner(hash, sig)
+}
+
+// VerifyTypedData checks whether typedData is signed by addr
+func VerifyTypedData(typedData *apitypes.TypedData, sig []byte, addr address.Address) bool {
+	signer, err := RecoverTypedDataSigner(typedData, sig)
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(signer, addr)
+}
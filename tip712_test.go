@@ -0,0 +1,220 @@
+package go_tronsdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+)
+
+func testTypedData(owner, spender, contract string, chainID *big.Int) *apitypes.TypedData {
+	return &apitypes.TypedData{
+		Types: apitypes.Types{
+			"EIP712Domain": {
+				{Name: "name", Type: "string"},
+				{Name: "version", Type: "string"},
+				{Name: "chainId", Type: "uint256"},
+				{Name: "verifyingContract", Type: "address"},
+			},
+			"Permit": {
+				{Name: "owner", Type: "address"},
+				{Name: "spender", Type: "address"},
+				{Name: "value", Type: "uint256"},
+				{Name: "nonce", Type: "uint256"},
+				{Name: "deadline", Type: "uint256"},
+				{Name: "holders", Type: "address[]"},
+			},
+		},
+		PrimaryType: "Permit",
+		Domain: apitypes.TypedDataDomain{
+			Name:              "USDT",
+			Version:           "1",
+			ChainId:           (*math.HexOrDecimal256)(chainID),
+			VerifyingContract: contract,
+		},
+		Message: apitypes.TypedDataMessage{
+			"owner":    owner,
+			"spender":  spender,
+			"value":    "1000000",
+			"nonce":    "0",
+			"deadline": "1700000000",
+			"holders":  []interface{}{owner, spender},
+		},
+	}
+}
+
+func TestTIP712Hash(t *testing.T) {
+	priv, _ := hex.DecodeString("4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318")
+	signer, _ := NewPrivateKeySigner(priv)
+	owner := signer.Address()
+	spender, _ := hex.DecodeString(testToHex)
+	contract := append([]byte{0x41}, bytes.Repeat([]byte{0xaa}, 20)...)
+	eth := func(b []byte) string { return "0x" + hex.EncodeToString(b[1:]) }
+	chainID := big.NewInt(0x2b6653dc)
+
+	// the same as EIP-712 for ethereum addresses and chainId in 32 bits
+	ethData := testTypedData(eth(owner), eth(spender), eth(contract), chainID)
+	expected, _, err := apitypes.TypedDataAndHash(*ethData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tronData := testTypedData(owner.String(), hex.EncodeToString(spender), eth(contract), chainID)
+	tronData.Domain.VerifyingContract = hex.EncodeToString(contract)
+	for _, td := range []*apitypes.TypedData{ethData, tronData} {
+		if h, err := TIP712Hash(td); err != nil || !bytes.Equal(h, expected) {
+			t.Fatalf("expecting %x, got %x %v", expected, h, err)
+		}
+	}
+	// chainId is masked
+	masked := testTypedData(owner.String(), eth(spender), eth(contract), chainID)
+	masked.Domain.ChainId = (*math.HexOrDecimal256)(new(big.Int).Or(new(big.Int).Lsh(big.NewInt(0xabcdef), 32), chainID))
+	if h, err := TIP712Hash(masked); err != nil || !bytes.Equal(h, expected) {
+		t.Fatalf("chainId should be masked, got %x %v", h, err)
+	}
+
+	sig, err := SignTypedData(context.Background(), signer, tronData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if addr, err := RecoverTypedDataSigner(ethData, sig); err != nil || !bytes.Equal(addr, owner) {
+		t.Fatalf("expecting signer %s, got %s %v", owner, addr, err)
+	}
+	tronData.Message["value"] = "1000001"
+	if VerifyTypedData(tronData, sig, owner) {
+		t.Fatal("tampered message verified")
+	}
+}
+
+func TestTIP712Hash_TrcToken(t *testing.T) {
+	td := &apitypes.TypedData{
+		Types: apitypes.Types{
+			"EIP712Domain": {{Name: "name", Type: "string"}},
+			"Transfer": {
+				{Name: "token", Type: "trcToken"},
+				{Name: "amount", Type: "uint256"},
+			},
+		},
+		PrimaryType: "Transfer",
+		Domain:      apitypes.TypedDataDomain{Name: "test"},
+		Message:     apitypes.TypedDataMessage{"token": "1002000", "amount": "1"},
+	}
+	h, err := TIP712Hash(td)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// trcToken is encoded as uint256, but the type hash is different
+	td.Types["Transfer"][0].Type = "uint256"
+	if h2, err := TIP712Hash(td); err != nil || bytes.Equal(h, h2) {
+		t.Fatalf("type hash of trcToken should differ from uint256: %x %v", h2, err)
+	}
+	td.Message["token"] = "-1"
+	td.Types["Transfer"][0].Type = "trcToken"
+	if _, err = TIP712Hash(td); err == nil {
+		t.Fatal("expecting invalid trcToken")
+	}
+}
+
+// the example of EIP-712, signed by keccak256("cow")
+func testMailTypedData(contract, cow, bob string) *apitypes.TypedData {
+	return &apitypes.TypedData{
+		Types: apitypes.Types{
+			"EIP712Domain": {
+				{Name: "name", Type: "string"},
+				{Name: "version", Type: "string"},
+				{Name: "chainId", Type: "uint256"},
+				{Name: "verifyingContract", Type: "address"},
+			},
+			"Person": {
+				{Name: "name", Type: "string"},
+				{Name: "wallet", Type: "address"},
+			},
+			"Mail": {
+				{Name: "from", Type: "Person"},
+				{Name: "to", Type: "Person"},
+				{Name: "contents", Type: "string"},
+			},
+		},
+		PrimaryType: "Mail",
+		Domain: apitypes.TypedDataDomain{
+			Name:              "Ether Mail",
+			Version:           "1",
+			ChainId:           math.NewHexOrDecimal256(1),
+			VerifyingContract: contract,
+		},
+		Message: apitypes.TypedDataMessage{
+			"from":     map[string]interface{}{"name": "Cow", "wallet": cow},
+			"to":       map[string]interface{}{"name": "Bob", "wallet": bob},
+			"contents": "Hello, Bob!",
+		},
+	}
+}
+
+func TestTIP712Hash_EIP712Vector(t *testing.T) {
+	expected, _ := hex.DecodeString("be609aee343fb3c4b28e1df9e632fca64fcfaede20f02e86244efddf30957bd2")
+	expectedSig, _ := hex.DecodeString("4355c47d63924e8a72e509b65029052eb6c299d53a04e167c5775fd466751c9d" +
+		"07299936d304c153f6443dfa05f40ff007d72911b6f72307f996231605b91562" + "1c")
+	tron := func(eth string) string {
+		b, _ := hex.DecodeString(eth[2:])
+		return address.Address(append([]byte{address.TronBytePrefix}, b...)).String()
+	}
+	contract, cow, bob := "0xCcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC", "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826",
+		"0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB"
+	signer, _ := NewPrivateKeySigner(crypto.Keccak256([]byte("cow")))
+	for _, td := range []*apitypes.TypedData{
+		testMailTypedData(contract, cow, bob),
+		// base58 addresses of TRON
+		testMailTypedData(tron(contract), tron(cow), tron(bob)),
+	} {
+		h, err := TIP712Hash(td)
+		if err != nil || !bytes.Equal(h, expected) {
+			t.Fatalf("expecting %x, got %x %v", expected, h, err)
+		}
+		sig, err := SignTypedData(context.Background(), signer, td)
+		if err != nil || !bytes.Equal(sig, expectedSig) {
+			t.Fatalf("expecting signature %x, got %x %v", expectedSig, sig, err)
+		}
+		if !VerifyTypedData(td, sig, address.HexToAddress("41"+cow[2:])) {
+			t.Fatal("verify failed")
+		}
+	}
+}
+
+func TestTIP712Hash_NestedArray(t *testing.T) {
+	td := &apitypes.TypedData{
+		Types: apitypes.Types{
+			"EIP712Domain": {{Name: "name", Type: "string"}},
+			"Matrix": {
+				{Name: "rows", Type: "uint256[][]"},
+				{Name: "tokens", Type: "trcToken[2][]"},
+			},
+		},
+		PrimaryType: "Matrix",
+		Domain:      apitypes.TypedDataDomain{Name: "test"},
+		Message: apitypes.TypedDataMessage{
+			"rows":   []interface{}{[]interface{}{"1", "2"}, []interface{}{}, []interface{}{"3"}},
+			"tokens": []interface{}{[]interface{}{"1002000", "1002001"}},
+		},
+	}
+	word := func(v int64) []byte { return common.LeftPadBytes(big.NewInt(v).Bytes(), 32) }
+	concat := func(bs ...[]byte) []byte { return bytes.Join(bs, nil) }
+	rows := crypto.Keccak256(
+		crypto.Keccak256(concat(word(1), word(2))),
+		crypto.Keccak256(),
+		crypto.Keccak256(word(3)))
+	tokens := crypto.Keccak256(crypto.Keccak256(concat(word(1002000), word(1002001))))
+	expected := crypto.Keccak256(crypto.Keccak256([]byte("Matrix(uint256[][] rows,trcToken[2][] tokens)")), rows, tokens)
+	if h, err := TIP712HashStruct(td, "Matrix", td.Message); err != nil || !bytes.Equal(h, expected) {
+		t.Fatalf("expecting %x, got %x %v", expected, h, err)
+	}
+	td.Message["rows"] = []interface{}{"1"}
+	if _, err := TIP712HashStruct(td, "Matrix", td.Message); err == nil {
+		t.Fatal("expecting item of rows not an array")
+	}
+}
@@ -0,0 +1,39 @@
+package go_tronsdk
+
+import (
+	"bytes"
+	"context"
+	"errors"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+)
+
+// TransferTRX sends amountSun (1 TRX = 1,000,000 sun) from the signer to the address. The
+// recipient must have been activated, unless opts.ActivateRecipient is set. The signed
+// transaction is returned with its txid.
+func (c *TronClient) TransferTRX(ctx context.Context, signer Signer, to address.Address, amountSun int64,
+	opts *TxOptions) (*api.TransactionExtention, error) {
+	if signer == nil {
+		return nil, errors.New("nil signer")
+	}
+	if !to.IsValid() {
+		return nil, errors.New("invalid recipient address")
+	}
+	if amountSun <= 0 {
+		return nil, errors.New("amount should be positive")
+	}
+	from := signer.Address()
+	if bytes.Equal(from, to) {
+		return nil, errors.New("cannot transfer to self")
+	}
+	if err := c.checkActivated(ctx, to, opts); err != nil {
+		return nil, err
+	}
+	return c.sendContract(ctx, signer, core.Transaction_Contract_TransferContract, &core.TransferContract{
+		OwnerAddress: from,
+		ToAddress:    to,
+		Amount:       amountSun,
+	}, opts)
+}
@@ -0,0 +1,80 @@
+package go_tronsdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+)
+
+func TestTronClient_TransferTRX(t *testing.T) {
+	w := &fakeWallet{name: "fake", height: 0x1234}
+	client, err := NewClient(context.Background(), WithBackend("fake", NewGrpcBackend(w)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = client.Close()
+	}()
+	priv, _ := hex.DecodeString("4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318")
+	signer, err := NewPrivateKeySigner(priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	to, _ := hex.DecodeString(testToHex)
+
+	txx, err := client.TransferTRX(context.Background(), signer, to, 1_000_000, &TxOptions{Memo: []byte("hi")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(w.broadcasts) != 1 || w.broadcasts[0] != txx.Transaction {
+		t.Fatal("signed transaction should be broadcast")
+	}
+	raw := txx.Transaction.RawData
+	if !bytes.Equal(raw.RefBlockBytes, []byte{0x12, 0x34}) || string(raw.Data) != "hi" ||
+		raw.Expiration != 1700000000000+DefaultTxExpiration.Milliseconds() {
+		t.Fatalf("unexpected raw data %v", raw)
+	}
+	tc := new(core.TransferContract)
+	if err = raw.Contract[0].Parameter.UnmarshalTo(tc); err != nil {
+		t.Fatal(err)
+	}
+	if tc.Amount != 1_000_000 || !bytes.Equal(tc.ToAddress, to) || !bytes.Equal(tc.OwnerAddress, signer.Address()) {
+		t.Fatalf("unexpected contract %v", tc)
+	}
+	txId, _ := HashMessage(raw)
+	if !bytes.Equal(txId, txx.Txid) {
+		t.Fatal("txid mismatch")
+	}
+	pub, err := crypto.SigToPub(txId, txx.Transaction.Signature[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(address.PubkeyToAddress(*pub), signer.Address()) {
+		t.Fatal("signature not by the signer")
+	}
+
+	w.inactive = true
+	if _, err = client.TransferTRX(context.Background(), signer, to, 1, nil); !errors.Is(err, ErrAccountNotActivated) {
+		t.Fatalf("expecting ErrAccountNotActivated, got %v", err)
+	}
+	if _, err = client.TransferTRX(context.Background(), signer, to, 1, &TxOptions{ActivateRecipient: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	// fee limit is only for contract calls
+	for _, feeLimit := range []int64{AutoFeeLimit, 10_000_000} {
+		txx, err = client.TransferTRX(context.Background(), signer, to, 1, &TxOptions{FeeLimit: feeLimit, ActivateRecipient: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if txx.Transaction.RawData.FeeLimit != 0 {
+			t.Fatalf("expecting no fee limit of the transfer, got %d", txx.Transaction.RawData.FeeLimit)
+		}
+	}
+}
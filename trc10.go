@@ -0,0 +1,164 @@
+package go_tronsdk
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+)
+
+var ErrAssetNotFound = errors.New("trc10 asset not found")
+
+// TokenValue is the TRC10 token sent with a contract call
+type TokenValue struct {
+	TokenId int64
+	Amount  int64
+}
+
+// TRC10Asset is the metadata of a TRC10 token
+type TRC10Asset struct {
+	Id          string
+	Name        string
+	Abbr        string
+	Precision   int32
+	Owner       address.Address
+	TotalSupply int64
+	Description string
+	Url         string
+}
+
+func newTRC10Asset(a *core.AssetIssueContract) *TRC10Asset {
+	return &TRC10Asset{
+		Id:          a.Id,
+		Name:        string(a.Name),
+		Abbr:        string(a.Abbr),
+		Precision:   a.Precision,
+		Owner:       a.OwnerAddress,
+		TotalSupply: a.TotalSupply,
+		Description: string(a.Description),
+		Url:         string(a.Url),
+	}
+}
+
+// Format converts the integer amount of the token to decimal string with its precision
+func (a *TRC10Asset) Format(amount int64) string {
+	return FormatUnits(big.NewInt(amount), uint8(a.Precision))
+}
+
+// Parse converts decimal string amount to the integer amount of the token
+func (a *TRC10Asset) Parse(amount string) (int64, error) {
+	v, err := ParseUnits(amount, uint8(a.Precision))
+	if err != nil {
+		return 0, err
+	}
+	if !v.IsInt64() {
+		return 0, fmt.Errorf("amount %s overflows", amount)
+	}
+	return v.Int64(), nil
+}
+
+// TRC10Holding is the balance of a TRC10 token of an account
+type TRC10Holding struct {
+	Asset   *TRC10Asset
+	Balance int64
+}
+
+func (h *TRC10Holding) String() string {
+	return fmt.Sprintf("%s %s(%s)", h.Asset.Format(h.Balance), h.Asset.Abbr, h.Asset.Id)
+}
+
+func (c *TronClient) GetAssetIssueById(cctx context.Context, id string, cons ...Consistency) (*core.AssetIssueContract, error) {
+	return _consistentRun(cctx, c, "GetAssetIssueById", cons, func(ctx context.Context, b ReadBackend) (*core.AssetIssueContract, error) {
+		return b.GetAssetIssueById(ctx, id)
+	})
+}
+
+// TRC10Asset gets the metadata of the token by id, which is cached since it is never changed
+// except the description and url.
+func (c *TronClient) TRC10Asset(ctx context.Context, id string) (*TRC10Asset, error) {
+	if v, ok := c.assets.Load(id); ok {
+		return v.(*TRC10Asset), nil
+	}
+	a, err := c.GetAssetIssueById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if a == nil || a.Id == "" {
+		return nil, fmt.Errorf("%w: %s", ErrAssetNotFound, id)
+	}
+	asset := newTRC10Asset(a)
+	c.assets.Store(id, asset)
+	return asset, nil
+}
+
+// TRC10Balances lists the TRC10 tokens held by owner with their metadata, in the order of ids
+func (c *TronClient) TRC10Balances(ctx context.Context, owner address.Address, cons ...Consistency) ([]*TRC10Holding, error) {
+	acc, err := c.GetAccount(ctx, owner, cons...)
+	if err != nil {
+		return nil, err
+	}
+	if acc == nil || len(acc.AssetV2) == 0 {
+		return nil, nil
+	}
+	ids := make([]string, 0, len(acc.AssetV2))
+	for id, balance := range acc.AssetV2 {
+		if balance > 0 {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		ni, erri := strconv.ParseInt(ids[i], 10, 64)
+		nj, errj := strconv.ParseInt(ids[j], 10, 64)
+		if erri != nil || errj != nil {
+			return ids[i] < ids[j]
+		}
+		return ni < nj
+	})
+	ret := make([]*TRC10Holding, 0, len(ids))
+	for _, id := range ids {
+		asset, err := c.TRC10Asset(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("asset %s: %w", id, err)
+		}
+		ret = append(ret, &TRC10Holding{Asset: asset, Balance: acc.AssetV2[id]})
+	}
+	return ret, nil
+}
+
+// TransferTRC10 sends amount (with precision applied) of the TRC10 token from the signer to the
+// address. The recipient must have been activated, unless opts.ActivateRecipient is set.
+func (c *TronClient) TransferTRC10(ctx context.Context, signer Signer, to address.Address, assetId string, amount int64,
+	opts *TxOptions) (*api.TransactionExtention, error) {
+	if signer == nil {
+		return nil, errors.New("nil signer")
+	}
+	if !to.IsValid() {
+		return nil, errors.New("invalid recipient address")
+	}
+	if amount <= 0 {
+		return nil, errors.New("amount should be positive")
+	}
+	if _, err := strconv.ParseInt(assetId, 10, 64); err != nil {
+		return nil, fmt.Errorf("invalid asset id %q", assetId)
+	}
+	from := signer.Address()
+	if bytes.Equal(from, to) {
+		return nil, errors.New("cannot transfer to self")
+	}
+	if err := c.checkActivated(ctx, to, opts); err != nil {
+		return nil, err
+	}
+	return c.sendContract(ctx, signer, core.Transaction_Contract_TransferAssetContract, &core.TransferAssetContract{
+		AssetName:    []byte(assetId),
+		OwnerAddress: from,
+		ToAddress:    to,
+		Amount:       amount,
+	}, opts)
+}
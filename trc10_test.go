@@ -0,0 +1,98 @@
+package go_tronsdk
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"google.golang.org/grpc"
+)
+
+type trc10Wallet struct {
+	*fakeWallet
+	assetCalls int
+}
+
+func (w *trc10Wallet) GetAccount(_ context.Context, in *core.Account, _ ...grpc.CallOption) (*core.Account, error) {
+	return &core.Account{Address: in.Address, AssetV2: map[string]int64{"1002000": 1500000, "1000001": 7, "1000002": 0}}, nil
+}
+
+func (w *trc10Wallet) GetAssetIssueById(_ context.Context, in *api.BytesMessage, _ ...grpc.CallOption) (*core.AssetIssueContract, error) {
+	w.assetCalls++
+	switch string(in.Value) {
+	case "1002000":
+		return &core.AssetIssueContract{Id: "1002000", Name: []byte("BitTorrent"), Abbr: []byte("BTT"), Precision: 6}, nil
+	case "1000001":
+		return &core.AssetIssueContract{Id: "1000001", Name: []byte("SEED"), Abbr: []byte("SEED")}, nil
+	default:
+		return &core.AssetIssueContract{}, nil
+	}
+}
+
+func TestTronClient_TRC10(t *testing.T) {
+	w := &trc10Wallet{fakeWallet: &fakeWallet{name: "fake", height: 100}}
+	client, err := NewClient(context.Background(), WithBackend("fake", NewGrpcBackend(w)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = client.Close()
+	}()
+	owner, _ := hex.DecodeString(testOwnerHex)
+	holdings, err := client.TRC10Balances(context.Background(), owner)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(holdings) != 2 || holdings[0].Asset.Id != "1000001" || holdings[1].String() != "1.5 BTT(1002000)" {
+		t.Fatalf("unexpected holdings %v", holdings)
+	}
+	if _, err = client.TRC10Balances(context.Background(), owner); err != nil || w.assetCalls != 2 {
+		t.Fatalf("asset metadata should be cached, calls:%d %v", w.assetCalls, err)
+	}
+	if _, err = client.TRC10Asset(context.Background(), "1000003"); err == nil {
+		t.Fatal("expecting asset not found")
+	}
+
+	priv, _ := hex.DecodeString("4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318")
+	signer, _ := NewPrivateKeySigner(priv)
+	to, _ := hex.DecodeString(testToHex)
+	txx, err := client.TransferTRC10(context.Background(), signer, to, "1002000", 1000000, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tac := new(core.TransferAssetContract)
+	if err = txx.Transaction.RawData.Contract[0].Parameter.UnmarshalTo(tac); err != nil {
+		t.Fatal(err)
+	}
+	if string(tac.AssetName) != "1002000" || tac.Amount != 1000000 ||
+		txx.Transaction.RawData.Contract[0].Type != core.Transaction_Contract_TransferAssetContract {
+		t.Fatalf("unexpected contract %v", tac)
+	}
+	if _, err = client.TransferTRC10(context.Background(), signer, to, "BTT", 1, nil); err == nil {
+		t.Fatal("expecting invalid asset id")
+	}
+}
+
+func TestHttpClient_GetAssetIssueById(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		if r.URL.Path != "/wallet/getassetissuebyid" || req["value"] != "1002000" {
+			t.Errorf("unexpected request %s %v", r.URL.Path, req)
+		}
+		_, _ = w.Write([]byte(`{"owner_address":"` + testOwnerHex + `","name":"426974546f7272656e74","abbr":"425454","total_supply":990000000000000000,"precision":6,"id":"1002000"}`))
+	}))
+	defer server.Close()
+	asset, err := NewHttpClient(server.URL, 1).GetAssetIssueById(context.Background(), "1002000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(asset.Name) != "BitTorrent" || string(asset.Abbr) != "BTT" || asset.Precision != 6 || asset.TotalSupply != 990000000000000000 {
+		t.Fatalf("unexpected asset %v", asset)
+	}
+}
@@ -0,0 +1,335 @@
+package go_tronsdk
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+)
+
+var (
+	trc20BalanceOf    = methodId("balanceOf(address)")
+	trc20Allowance    = methodId("allowance(address,address)")
+	trc20Decimals     = methodId("decimals()")
+	trc20Symbol       = methodId("symbol()")
+	trc20Name         = methodId("name()")
+	trc20TotalSupply  = methodId("totalSupply()")
+	trc20Transfer     = methodId("transfer(address,uint256)")
+	trc20Approve      = methodId("approve(address,uint256)")
+	trc20TransferFrom = methodId("transferFrom(address,address,uint256)")
+
+	TRC20TransferTopic = crypto.Keccak256([]byte("Transfer(address,address,uint256)"))
+	TRC20ApprovalTopic = crypto.Keccak256([]byte("Approval(address,address,uint256)"))
+)
+
+// zeroAddress is the owner of the constant calls without a caller, T9yD14Nj9j7xAB4dbGeiX9h8unkKHxuWwb
+var zeroAddress = address.Address(append([]byte{address.TronBytePrefix}, make([]byte, 20)...))
+
+func methodId(signature string) []byte {
+	return crypto.Keccak256([]byte(signature))[:4]
+}
+
+// abiAddress encodes a TRON address as an ABI address word, without the 0x41 prefix
+func abiAddress(addr address.Address) []byte {
+	word := make([]byte, 32)
+	if len(addr) == address.AddressLength {
+		copy(word[12:], addr[1:])
+	} else {
+		copy(word[32-len(addr):], addr)
+	}
+	return word
+}
+
+func abiUint(v *big.Int) ([]byte, error) {
+	if v == nil || v.Sign() < 0 || v.BitLen() > 256 {
+		return nil, errors.New("invalid uint256")
+	}
+	word := make([]byte, 32)
+	v.FillBytes(word)
+	return word, nil
+}
+
+// wordToAddress decodes an ABI address word or a log topic to TRON address
+func wordToAddress(word []byte) address.Address {
+	if len(word) < 20 {
+		return nil
+	}
+	return append([]byte{address.TronBytePrefix}, word[len(word)-20:]...)
+}
+
+// abiString decodes a string return value, bytes32 returned by some early tokens is accepted
+func abiString(data []byte) (string, error) {
+	if len(data) == 32 {
+		return string(bytes.TrimRight(data, "\x00")), nil
+	}
+	if len(data) < 64 {
+		return "", errors.New("invalid abi string")
+	}
+	offset := new(big.Int).SetBytes(data[:32])
+	if !offset.IsUint64() || offset.Uint64()+32 > uint64(len(data)) {
+		return "", errors.New("invalid abi string offset")
+	}
+	start := offset.Uint64()
+	length := new(big.Int).SetBytes(data[start : start+32])
+	if !length.IsUint64() || start+32+length.Uint64() > uint64(len(data)) {
+		return "", errors.New("invalid abi string length")
+	}
+	return string(data[start+32 : start+32+length.Uint64()]), nil
+}
+
+// ParseUnits converts decimal string amount, such as "1.5", to the integer amount of token
+// with decimals
+func ParseUnits(amount string, decimals uint8) (*big.Int, error) {
+	amount = strings.TrimSpace(amount)
+	intPart, fracPart, _ := strings.Cut(amount, ".")
+	if strings.TrimPrefix(intPart, "-")+fracPart == "" {
+		return nil, fmt.Errorf("invalid amount %q", amount)
+	}
+	if len(fracPart) > int(decimals) {
+		if strings.TrimRight(fracPart[decimals:], "0") != "" {
+			return nil, fmt.Errorf("%s has more than %d decimals", amount, decimals)
+		}
+		fracPart = fracPart[:decimals]
+	}
+	s := intPart + fracPart + strings.Repeat("0", int(decimals)-len(fracPart))
+	v, ok := new(big.Int).SetString(s, 10)
+	if !ok || strings.HasPrefix(fracPart, "-") || strings.HasPrefix(fracPart, "+") {
+		return nil, fmt.Errorf("invalid amount %q", amount)
+	}
+	return v, nil
+}
+
+// FormatUnits converts the integer amount of token with decimals to decimal string
+func FormatUnits(v *big.Int, decimals uint8) string {
+	if v == nil {
+		return "0"
+	}
+	s := new(big.Int).Abs(v).String()
+	if decimals > 0 {
+		if len(s) <= int(decimals) {
+			s = strings.Repeat("0", int(decimals)-len(s)+1) + s
+		}
+		intPart, fracPart := s[:len(s)-int(decimals)], strings.TrimRight(s[len(s)-int(decimals):], "0")
+		s = intPart
+		if fracPart != "" {
+			s += "." + fracPart
+		}
+	}
+	if v.Sign() < 0 {
+		s = "-" + s
+	}
+	return s
+}
+
+// TRC20Transfer is a Transfer event, or an Approval event with From as owner and To as spender
+type TRC20Transfer struct {
+	Token address.Address
+	From  address.Address
+	To    address.Address
+	Value *big.Int
+}
+
+// TRC20Receipt is the receipt of a transaction with the TRC20 events of the token
+type TRC20Receipt struct {
+	*Receipt
+	Transfers []*TRC20Transfer
+	Approvals []*TRC20Transfer
+}
+
+// ParseTRC20Events decodes the Transfer and Approval events of token from logs, all tokens if
+// token is nil
+func ParseTRC20Events(token address.Address, logs []*core.TransactionInfo_Log) (transfers, approvals []*TRC20Transfer) {
+	for _, log := range logs {
+		if log == nil || len(log.Topics) != 3 || len(log.Data) != 32 {
+			continue
+		}
+		logAddr := wordToAddress(log.Address)
+		if len(token) > 0 && !bytes.Equal(logAddr, token) {
+			continue
+		}
+		ev := &TRC20Transfer{
+			Token: logAddr,
+			From:  wordToAddress(log.Topics[1]),
+			To:    wordToAddress(log.Topics[2]),
+			Value: new(big.Int).SetBytes(log.Data),
+		}
+		switch {
+		case bytes.Equal(log.Topics[0], TRC20TransferTopic):
+			transfers = append(transfers, ev)
+		case bytes.Equal(log.Topics[0], TRC20ApprovalTopic):
+			approvals = append(approvals, ev)
+		}
+	}
+	return transfers, approvals
+}
+
+// TRC20 is the token contract at Address. Amounts are integers with decimals applied, which
+// could be converted by ParseUnits/FormatUnits or ToUnits/FromUnits.
+type TRC20 struct {
+	client  *TronClient
+	Address address.Address
+	// Caller is the msg.sender of the constant calls, the zero address if nil as TronWeb
+	Caller address.Address
+
+	lock     sync.Mutex
+	decimals *uint8
+}
+
+func NewTRC20(client *TronClient, contract address.Address) *TRC20 {
+	return &TRC20{client: client, Address: contract}
+}
+
+func (t *TRC20) call(ctx context.Context, cons []Consistency, data ...[]byte) ([]byte, error) {
+	caller := t.Caller
+	if caller == nil {
+		caller = zeroAddress
+	}
+	txx, err := t.client.CallContract(ctx, caller, t.Address, bytes.Join(data, nil), cons...)
+	if err != nil {
+		return nil, err
+	}
+	if txx.Result != nil && (!txx.Result.Result || txx.Result.Code > 0) {
+		return nil, fmt.Errorf("call %s failed: %w", t.Address, (*TxReturn)(txx.Result).Err())
+	}
+	if len(txx.ConstantResult) == 0 {
+		return nil, fmt.Errorf("call %s: no result", t.Address)
+	}
+	return txx.ConstantResult[0], nil
+}
+
+func (t *TRC20) callUint(ctx context.Context, cons []Consistency, data ...[]byte) (*big.Int, error) {
+	ret, err := t.call(ctx, cons, data...)
+	if err != nil {
+		return nil, err
+	}
+	if len(ret) < 32 {
+		return nil, fmt.Errorf("invalid uint256 result %x", ret)
+	}
+	return new(big.Int).SetBytes(ret[:32]), nil
+}
+
+func (t *TRC20) BalanceOf(ctx context.Context, owner address.Address, cons ...Consistency) (*big.Int, error) {
+	return t.callUint(ctx, cons, trc20BalanceOf, abiAddress(owner))
+}
+
+func (t *TRC20) Allowance(ctx context.Context, owner, spender address.Address, cons ...Consistency) (*big.Int, error) {
+	return t.callUint(ctx, cons, trc20Allowance, abiAddress(owner), abiAddress(spender))
+}
+
+func (t *TRC20) TotalSupply(ctx context.Context, cons ...Consistency) (*big.Int, error) {
+	return t.callUint(ctx, cons, trc20TotalSupply)
+}
+
+// Decimals is cached after the first successful call, which is not locked so that concurrent
+// callers are not blocked by a slow node
+func (t *TRC20) Decimals(ctx context.Context) (uint8, error) {
+	t.lock.Lock()
+	cached := t.decimals
+	t.lock.Unlock()
+	if cached != nil {
+		return *cached, nil
+	}
+	v, err := t.callUint(ctx, nil, trc20Decimals)
+	if err != nil {
+		return 0, err
+	}
+	if !v.IsUint64() || v.Uint64() > 77 {
+		return 0, fmt.Errorf("invalid decimals %s", v)
+	}
+	d := uint8(v.Uint64())
+	t.lock.Lock()
+	t.decimals = &d
+	t.lock.Unlock()
+	return d, nil
+}
+
+func (t *TRC20) Symbol(ctx context.Context) (string, error) {
+	ret, err := t.call(ctx, nil, trc20Symbol)
+	if err != nil {
+		return "", err
+	}
+	return abiString(ret)
+}
+
+func (t *TRC20) Name(ctx context.Context) (string, error) {
+	ret, err := t.call(ctx, nil, trc20Name)
+	if err != nil {
+		return "", err
+	}
+	return abiString(ret)
+}
+
+// ToUnits converts decimal string amount, such as "1.5", to the integer amount of the token
+func (t *TRC20) ToUnits(ctx context.Context, amount string) (*big.Int, error) {
+	decimals, err := t.Decimals(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUnits(amount, decimals)
+}
+
+// FromUnits converts the integer amount of the token to decimal string
+func (t *TRC20) FromUnits(ctx context.Context, v *big.Int) (string, error) {
+	decimals, err := t.Decimals(ctx)
+	if err != nil {
+		return "", err
+	}
+	return FormatUnits(v, decimals), nil
+}
+
+func (t *TRC20) send(ctx context.Context, signer Signer, opts *TxOptions, data ...[]byte) (*api.TransactionExtention, error) {
+	if signer == nil {
+		return nil, errors.New("nil signer")
+	}
+	return t.client.triggerContract(ctx, signer, t.Address, 0, bytes.Join(data, nil), nil, opts)
+}
+
+// Transfer amount of token from the signer to the address, opts.FeeLimit should be set
+func (t *TRC20) Transfer(ctx context.Context, signer Signer, to address.Address, amount *big.Int,
+	opts *TxOptions) (*api.TransactionExtention, error) {
+	value, err := abiUint(amount)
+	if err != nil {
+		return nil, err
+	}
+	return t.send(ctx, signer, opts, trc20Transfer, abiAddress(to), value)
+}
+
+// Approve spender to transfer amount of token from the signer
+func (t *TRC20) Approve(ctx context.Context, signer Signer, spender address.Address, amount *big.Int,
+	opts *TxOptions) (*api.TransactionExtention, error) {
+	value, err := abiUint(amount)
+	if err != nil {
+		return nil, err
+	}
+	return t.send(ctx, signer, opts, trc20Approve, abiAddress(spender), value)
+}
+
+// TransferFrom transfers amount of token from the address to the address, with the allowance
+// of signer
+func (t *TRC20) TransferFrom(ctx context.Context, signer Signer, from, to address.Address, amount *big.Int,
+	opts *TxOptions) (*api.TransactionExtention, error) {
+	value, err := abiUint(amount)
+	if err != nil {
+		return nil, err
+	}
+	return t.send(ctx, signer, opts, trc20TransferFrom, abiAddress(from), abiAddress(to), value)
+}
+
+// WaitReceipt waits for the transaction to be packed, and decodes the events of the token
+func (t *TRC20) WaitReceipt(ctx context.Context, txId []byte, cons ...Consistency) (*TRC20Receipt, error) {
+	rpt, err := t.client.WaitReceipt(ctx, txId, cons...)
+	if err != nil {
+		return nil, err
+	}
+	ret := &TRC20Receipt{Receipt: rpt}
+	ret.Transfers, ret.Approvals = ParseTRC20Events(t.Address, rpt.Logs)
+	return ret, nil
+}
@@ -0,0 +1,186 @@
+package go_tronsdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestParseUnits(t *testing.T) {
+	tests := []struct {
+		amount   string
+		decimals uint8
+		want     string
+		format   string
+	}{
+		{"1.5", 6, "1500000", "1.5"},
+		{"0.000001", 6, "1", "0.000001"},
+		{"12", 0, "12", "12"},
+		{"3.10", 2, "310", "3.1"},
+		{".25", 18, "250000000000000000", "0.25"},
+	}
+	for _, test := range tests {
+		v, err := ParseUnits(test.amount, test.decimals)
+		if err != nil {
+			t.Fatalf("%s: %v", test.amount, err)
+		}
+		if v.String() != test.want {
+			t.Fatalf("%s: expecting %s, got %s", test.amount, test.want, v)
+		}
+		if s := FormatUnits(v, test.decimals); s != test.format {
+			t.Fatalf("%s: expecting %s, got %s", test.amount, test.format, s)
+		}
+	}
+	for _, bad := range []string{"", "1.0000001", "abc", "1.-2", "."} {
+		if _, err := ParseUnits(bad, 6); err == nil {
+			t.Fatalf("%q should be invalid", bad)
+		}
+	}
+}
+
+type trc20Wallet struct {
+	*fakeWallet
+	results map[string][]byte // by method id in hex
+	calls   [][]byte
+	callers [][]byte
+}
+
+func (w *trc20Wallet) TriggerConstantContract(_ context.Context, in *core.TriggerSmartContract, _ ...grpc.CallOption) (*api.TransactionExtention, error) {
+	w.calls = append(w.calls, in.Data)
+	w.callers = append(w.callers, in.OwnerAddress)
+	ret, ok := w.results[hex.EncodeToString(in.Data[:4])]
+	if !ok {
+		return &api.TransactionExtention{Result: &api.Return{Code: api.Return_CONTRACT_VALIDATE_ERROR, Message: []byte("revert")}}, nil
+	}
+	return &api.TransactionExtention{Result: &api.Return{Result: true}, ConstantResult: [][]byte{ret}}, nil
+}
+
+func TestTRC20_Calls(t *testing.T) {
+	word := func(v int64) []byte {
+		return new(big.Int).SetInt64(v).FillBytes(make([]byte, 32))
+	}
+	symbol := append(append(word(32), word(4)...), []byte("USDT")...)
+	symbol = append(symbol, make([]byte, 28)...)
+	w := &trc20Wallet{fakeWallet: &fakeWallet{name: "fake"}, results: map[string][]byte{
+		hex.EncodeToString(trc20BalanceOf): word(1_500_000),
+		hex.EncodeToString(trc20Decimals):  word(6),
+		hex.EncodeToString(trc20Symbol):    symbol,
+	}}
+	client, err := NewClient(context.Background(), WithBackend("fake", NewGrpcBackend(w)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = client.Close()
+	}()
+	contract, _ := hex.DecodeString(testToHex)
+	owner, _ := hex.DecodeString(testOwnerHex)
+	token := NewTRC20(client, contract)
+
+	balance, err := token.BalanceOf(context.Background(), owner)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s, err := token.FromUnits(context.Background(), balance); err != nil || s != "1.5" {
+		t.Fatalf("expecting 1.5, got %s %v", s, err)
+	}
+	if !bytes.Equal(w.calls[0][16:36], owner[1:]) || !bytes.Equal(w.calls[0][:4], trc20BalanceOf) {
+		t.Fatalf("unexpected calldata %x", w.calls[0])
+	}
+	if s, err := token.Symbol(context.Background()); err != nil || s != "USDT" {
+		t.Fatalf("expecting USDT, got %s %v", s, err)
+	}
+	if _, err = token.Decimals(context.Background()); err != nil || len(w.calls) != 3 {
+		t.Fatalf("decimals should be cached, calls:%d %v", len(w.calls), err)
+	}
+	if _, err = token.Name(context.Background()); err == nil {
+		t.Fatal("expecting revert error")
+	}
+	if zero := address.Address(w.callers[0]).String(); zero != "T9yD14Nj9j7xAB4dbGeiX9h8unkKHxuWwb" {
+		t.Fatalf("expecting the zero address as the caller, got %s", zero)
+	}
+	token.Caller = owner
+	if _, err = token.BalanceOf(context.Background(), owner); err != nil || !bytes.Equal(w.callers[len(w.callers)-1], owner) {
+		t.Fatalf("expecting the owner as the caller, got %x %v", w.callers[len(w.callers)-1], err)
+	}
+}
+
+type receiptWallet struct {
+	*fakeWallet
+	errs []error
+}
+
+func (w *receiptWallet) GetTransactionInfoById(_ context.Context, in *api.BytesMessage, _ ...grpc.CallOption) (*core.TransactionInfo, error) {
+	w.calls++
+	if len(w.errs) == 0 {
+		return &core.TransactionInfo{Id: in.Value, BlockNumber: 100}, nil
+	}
+	err := w.errs[0]
+	w.errs = w.errs[1:]
+	return &core.TransactionInfo{}, err
+}
+
+func TestTronClient_WaitReceipt(t *testing.T) {
+	invalid := status.Error(codes.InvalidArgument, "invalid txid")
+	tests := []struct {
+		name  string
+		errs  []error
+		calls int
+		err   error
+	}{
+		{"pending", []error{nil, status.Error(codes.Unavailable, "down"), status.Error(codes.NotFound, "not found")}, 4, nil},
+		{"invalid", []error{nil, invalid, nil}, 2, invalid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &receiptWallet{fakeWallet: &fakeWallet{name: "fake", height: 100}, errs: tt.errs}
+			client, err := NewClient(context.Background(), WithBackend("fake", NewGrpcBackend(w)),
+				WithGetTxInterval(time.Millisecond))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer func() {
+				_ = client.Close()
+			}()
+			w.calls = 0
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+			receipt, err := client.WaitReceipt(ctx, []byte{1, 2, 3})
+			if w.calls != tt.calls || !errors.Is(err, tt.err) || (err == nil && receipt == nil) {
+				t.Fatalf("expecting %v after %d calls, got %v after %d calls", tt.err, tt.calls, err, w.calls)
+			}
+		})
+	}
+}
+
+func TestParseTRC20Events(t *testing.T) {
+	token, _ := hex.DecodeString(testToHex)
+	from, _ := hex.DecodeString(testOwnerHex)
+	value := big.NewInt(100).FillBytes(make([]byte, 32))
+	logs := []*core.TransactionInfo_Log{
+		{Address: token[1:], Topics: [][]byte{TRC20TransferTopic, abiAddress(from), abiAddress(token)}, Data: value},
+		{Address: token[1:], Topics: [][]byte{TRC20ApprovalTopic, abiAddress(from), abiAddress(token)}, Data: value},
+		{Address: from[1:], Topics: [][]byte{TRC20TransferTopic, abiAddress(from), abiAddress(token)}, Data: value},
+	}
+	transfers, approvals := ParseTRC20Events(token, logs)
+	if len(transfers) != 1 || len(approvals) != 1 {
+		t.Fatalf("expecting 1 transfer and 1 approval, got %d %d", len(transfers), len(approvals))
+	}
+	if !bytes.Equal(transfers[0].From, from) || !bytes.Equal(transfers[0].To, token) || transfers[0].Value.Int64() != 100 {
+		t.Fatalf("unexpected transfer %+v", transfers[0])
+	}
+	if transfers, _ = ParseTRC20Events(nil, logs); len(transfers) != 2 {
+		t.Fatalf("expecting transfers of all tokens, got %d", len(transfers))
+	}
+}
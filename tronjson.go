@@ -0,0 +1,433 @@
+package go_tronsdk
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/common"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// The JSON of TRON HTTP API is different from protojson in:
+//   - bytes are hex strings, or base58 for addresses and UTF-8 for names in visible mode
+//   - Any is {"type_url":..., "value":{...}} rather than {"@type":..., ...}
+//   - maps are lists of {"key":..., "value":...}
+// marshalTronJSON and unmarshalTronJSON convert between them by walking the message descriptors.
+
+const anyFullName = "google.protobuf.Any"
+
+// The bytes fields shown as base58 addresses or UTF-8 strings in visible mode, by the full name
+// of the field, the same as HttpSelfFormatFieldName of java-tron. Other bytes fields are hex.
+var (
+	visibleAddressFields = fieldNameSet(
+		// api
+		"protocol.DelegatedResourceMessage.fromAddress",
+		"protocol.DelegatedResourceMessage.toAddress",
+		"protocol.TransactionSignWeight.approved_list",
+		"protocol.TransactionApprovedList.approved_list",
+		"protocol.CanDelegatedMaxSizeRequestMessage.owner_address",
+		"protocol.GetAvailableUnfreezeCountRequestMessage.owner_address",
+		"protocol.CanWithdrawUnfreezeAmountRequestMessage.owner_address",
+		"protocol.PrivateParameters.transparent_from_address",
+		"protocol.PrivateParameters.transparent_to_address",
+		"protocol.PrivateParametersWithoutAsk.transparent_from_address",
+		"protocol.PrivateParametersWithoutAsk.transparent_to_address",
+		"protocol.PrivateShieldedTRC20Parameters.transparent_to_address",
+		"protocol.PrivateShieldedTRC20Parameters.shielded_TRC20_contract_address",
+		"protocol.PrivateShieldedTRC20ParametersWithoutAsk.transparent_to_address",
+		"protocol.PrivateShieldedTRC20ParametersWithoutAsk.shielded_TRC20_contract_address",
+		"protocol.ShieldedTRC20TriggerContractParameters.transparent_to_address",
+		"protocol.IvkDecryptTRC20Parameters.shielded_TRC20_contract_address",
+		"protocol.OvkDecryptTRC20Parameters.shielded_TRC20_contract_address",
+		"protocol.NfTRC20Parameters.shielded_TRC20_contract_address",
+		"protocol.DecryptNotesTRC20.NoteTx.transparent_to_address",
+		// contracts
+		"protocol.AccountCreateContract.owner_address",
+		"protocol.AccountCreateContract.account_address",
+		"protocol.AccountUpdateContract.owner_address",
+		"protocol.SetAccountIdContract.owner_address",
+		"protocol.AccountPermissionUpdateContract.owner_address",
+		"protocol.TransferContract.owner_address",
+		"protocol.TransferContract.to_address",
+		"protocol.TransferAssetContract.owner_address",
+		"protocol.TransferAssetContract.to_address",
+		"protocol.AssetIssueContract.owner_address",
+		"protocol.ParticipateAssetIssueContract.owner_address",
+		"protocol.ParticipateAssetIssueContract.to_address",
+		"protocol.UnfreezeAssetContract.owner_address",
+		"protocol.UpdateAssetContract.owner_address",
+		"protocol.VoteAssetContract.owner_address",
+		"protocol.VoteAssetContract.vote_address",
+		"protocol.VoteWitnessContract.owner_address",
+		"protocol.VoteWitnessContract.Vote.vote_address",
+		"protocol.WitnessCreateContract.owner_address",
+		"protocol.WitnessUpdateContract.owner_address",
+		"protocol.WithdrawBalanceContract.owner_address",
+		"protocol.UpdateBrokerageContract.owner_address",
+		"protocol.FreezeBalanceContract.owner_address",
+		"protocol.FreezeBalanceContract.receiver_address",
+		"protocol.UnfreezeBalanceContract.owner_address",
+		"protocol.UnfreezeBalanceContract.receiver_address",
+		"protocol.FreezeBalanceV2Contract.owner_address",
+		"protocol.UnfreezeBalanceV2Contract.owner_address",
+		"protocol.WithdrawExpireUnfreezeContract.owner_address",
+		"protocol.DelegateResourceContract.owner_address",
+		"protocol.DelegateResourceContract.receiver_address",
+		"protocol.UnDelegateResourceContract.owner_address",
+		"protocol.UnDelegateResourceContract.receiver_address",
+		"protocol.CancelAllUnfreezeV2Contract.owner_address",
+		"protocol.ProposalCreateContract.owner_address",
+		"protocol.ProposalApproveContract.owner_address",
+		"protocol.ProposalDeleteContract.owner_address",
+		"protocol.CreateSmartContract.owner_address",
+		"protocol.TriggerSmartContract.owner_address",
+		"protocol.TriggerSmartContract.contract_address",
+		"protocol.UpdateSettingContract.owner_address",
+		"protocol.UpdateSettingContract.contract_address",
+		"protocol.UpdateEnergyLimitContract.owner_address",
+		"protocol.UpdateEnergyLimitContract.contract_address",
+		"protocol.ClearABIContract.owner_address",
+		"protocol.ClearABIContract.contract_address",
+		"protocol.BuyStorageContract.owner_address",
+		"protocol.BuyStorageBytesContract.owner_address",
+		"protocol.SellStorageContract.owner_address",
+		"protocol.ExchangeCreateContract.owner_address",
+		"protocol.ExchangeInjectContract.owner_address",
+		"protocol.ExchangeWithdrawContract.owner_address",
+		"protocol.ExchangeTransactionContract.owner_address",
+		"protocol.MarketSellAssetContract.owner_address",
+		"protocol.MarketCancelOrderContract.owner_address",
+		"protocol.ShieldedTransferContract.transparent_from_address",
+		"protocol.ShieldedTransferContract.transparent_to_address",
+		// Tron
+		"protocol.AccountId.address",
+		"protocol.Vote.vote_address",
+		"protocol.Proposal.proposer_address",
+		"protocol.Proposal.approvals",
+		"protocol.Exchange.creator_address",
+		"protocol.Account.address",
+		"protocol.Key.address",
+		"protocol.DelegatedResource.from",
+		"protocol.DelegatedResource.to",
+		"protocol.DelegatedResourceAccountIndex.account",
+		"protocol.DelegatedResourceAccountIndex.fromAccounts",
+		"protocol.DelegatedResourceAccountIndex.toAccounts",
+		"protocol.Witness.address",
+		"protocol.Votes.address",
+		"protocol.BlockHeader.raw.witness_address",
+		"protocol.TransactionInfo.contract_address",
+		"protocol.TransactionInfo.Log.address",
+		"protocol.InternalTransaction.caller_address",
+		"protocol.InternalTransaction.transferTo_address",
+		"protocol.SmartContract.origin_address",
+		"protocol.SmartContract.contract_address",
+		"protocol.MarketOrder.owner_address",
+		"protocol.MarketAccountOrder.owner_address",
+		"protocol.AccountIdentifier.address",
+		"protocol.TransactionBalanceTrace.Operation.address",
+	)
+	visibleNameFields = fieldNameSet(
+		// api
+		"protocol.Return.message",
+		"protocol.Address.host",
+		"protocol.Note.memo",
+		// contracts
+		"protocol.AccountUpdateContract.account_name",
+		"protocol.SetAccountIdContract.account_id",
+		"protocol.TransferAssetContract.asset_name",
+		"protocol.AssetIssueContract.name",
+		"protocol.AssetIssueContract.abbr",
+		"protocol.AssetIssueContract.description",
+		"protocol.AssetIssueContract.url",
+		"protocol.ParticipateAssetIssueContract.asset_name",
+		"protocol.UpdateAssetContract.description",
+		"protocol.UpdateAssetContract.url",
+		"protocol.WitnessCreateContract.url",
+		"protocol.WitnessUpdateContract.update_url",
+		// Tron
+		"protocol.AccountId.name",
+		"protocol.Account.account_name",
+		"protocol.Account.account_id",
+		"protocol.Account.asset_issued_name",
+		"protocol.TransactionInfo.resMessage",
+	)
+)
+
+func fieldNameSet(names ...protoreflect.FullName) map[protoreflect.FullName]bool {
+	ret := make(map[protoreflect.FullName]bool, len(names))
+	for _, name := range names {
+		ret[name] = true
+	}
+	return ret
+}
+
+func marshalTronJSON(m proto.Message, visible bool) ([]byte, error) {
+	obj, err := marshalTronJSONObject(m, visible)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(obj)
+}
+
+func marshalTronJSONObject(m proto.Message, visible bool) (map[string]interface{}, error) {
+	bs, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	v, err := decodeJSONValue(bs)
+	if err != nil {
+		return nil, err
+	}
+	if v, err = toTronJSON(v, m.ProtoReflect().Descriptor(), visible); err != nil {
+		return nil, err
+	}
+	obj, _ := v.(map[string]interface{})
+	if obj == nil {
+		obj = make(map[string]interface{})
+	}
+	if visible {
+		obj["visible"] = true
+	}
+	return obj, nil
+}
+
+func unmarshalTronJSON(data []byte, m proto.Message, visible bool) error {
+	v, err := decodeJSONValue(data)
+	if err != nil {
+		return err
+	}
+	if obj, ok := v.(map[string]interface{}); ok {
+		if msg, ok := obj["Error"].(string); ok {
+			return errors.New(msg)
+		}
+	}
+	if v, err = fromTronJSON(v, m.ProtoReflect().Descriptor(), visible); err != nil {
+		return err
+	}
+	bs, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return protojson.UnmarshalOptions{DiscardUnknown: true}.Unmarshal(bs, m)
+}
+
+func decodeJSONValue(data []byte) (interface{}, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+func fromTronJSON(v interface{}, md protoreflect.MessageDescriptor, visible bool) (interface{}, error) {
+	obj, ok := v.(map[string]interface{})
+	if !ok {
+		return v, nil
+	}
+	if md.FullName() == anyFullName {
+		typeUrl, _ := obj["type_url"].(string)
+		if typeUrl == "" {
+			return nil, errors.New("type_url of Any missing")
+		}
+		mt, err := protoregistry.GlobalTypes.FindMessageByURL(typeUrl)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", typeUrl, err)
+		}
+		val, err := fromTronJSON(obj["value"], mt.Descriptor(), visible)
+		if err != nil {
+			return nil, err
+		}
+		ret, _ := val.(map[string]interface{})
+		if ret == nil {
+			ret = make(map[string]interface{})
+		}
+		ret["@type"] = typeUrl
+		return ret, nil
+	}
+	fields := md.Fields()
+	for k, fv := range obj {
+		fd := fields.ByName(protoreflect.Name(k))
+		if fd == nil {
+			fd = fields.ByJSONName(k)
+		}
+		if fd == nil || fv == nil {
+			continue
+		}
+		var err error
+		switch {
+		case fd.IsMap():
+			obj[k], err = mapFromTronJSON(fv, fd, visible)
+		case fd.IsList():
+			if list, ok := fv.([]interface{}); ok {
+				for i := range list {
+					if list[i], err = fieldFromTronJSON(list[i], fd, visible); err != nil {
+						break
+					}
+				}
+			}
+		default:
+			obj[k], err = fieldFromTronJSON(fv, fd, visible)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", k, err)
+		}
+	}
+	return obj, nil
+}
+
+func mapFromTronJSON(v interface{}, fd protoreflect.FieldDescriptor, visible bool) (interface{}, error) {
+	list, ok := v.([]interface{})
+	if !ok {
+		return v, nil
+	}
+	ret := make(map[string]interface{}, len(list))
+	for _, item := range list {
+		entry, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		val, err := fieldFromTronJSON(entry["value"], fd.MapValue(), visible)
+		if err != nil {
+			return nil, err
+		}
+		ret[fmt.Sprint(entry["key"])] = val
+	}
+	return ret, nil
+}
+
+func fieldFromTronJSON(v interface{}, fd protoreflect.FieldDescriptor, visible bool) (interface{}, error) {
+	switch fd.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return fromTronJSON(v, fd.Message(), visible)
+	case protoreflect.BytesKind:
+		s, ok := v.(string)
+		if !ok {
+			return v, nil
+		}
+		bs, err := tronBytesFromString(s, fd, visible)
+		if err != nil {
+			return nil, err
+		}
+		return base64.StdEncoding.EncodeToString(bs), nil
+	default:
+		return v, nil
+	}
+}
+
+func tronBytesFromString(s string, fd protoreflect.FieldDescriptor, visible bool) ([]byte, error) {
+	if visible {
+		switch {
+		case visibleNameFields[fd.FullName()]:
+			return []byte(s), nil
+		case visibleAddressFields[fd.FullName()]:
+			if addr, err := address.Base58ToAddress(s); err == nil {
+				return addr, nil
+			}
+			// such as the 20 bytes address of logs, which is kept in hex
+		}
+	}
+	bs, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid hex string %q", s)
+	}
+	return bs, nil
+}
+
+func toTronJSON(v interface{}, md protoreflect.MessageDescriptor, visible bool) (interface{}, error) {
+	obj, ok := v.(map[string]interface{})
+	if !ok {
+		return v, nil
+	}
+	if md.FullName() == anyFullName {
+		typeUrl, _ := obj["@type"].(string)
+		mt, err := protoregistry.GlobalTypes.FindMessageByURL(typeUrl)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", typeUrl, err)
+		}
+		delete(obj, "@type")
+		val, err := toTronJSON(obj, mt.Descriptor(), visible)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"type_url": typeUrl, "value": val}, nil
+	}
+	fields := md.Fields()
+	for k, fv := range obj {
+		fd := fields.ByName(protoreflect.Name(k))
+		if fd == nil {
+			continue
+		}
+		var err error
+		switch {
+		case fd.IsMap():
+			if m, ok := fv.(map[string]interface{}); ok {
+				list := make([]interface{}, 0, len(m))
+				for mk, mv := range m {
+					if mv, err = fieldToTronJSON(mv, fd.MapValue(), visible); err != nil {
+						break
+					}
+					list = append(list, map[string]interface{}{"key": mk, "value": mv})
+				}
+				obj[k] = list
+			}
+		case fd.IsList():
+			if list, ok := fv.([]interface{}); ok {
+				for i := range list {
+					if list[i], err = fieldToTronJSON(list[i], fd, visible); err != nil {
+						break
+					}
+				}
+			}
+		default:
+			obj[k], err = fieldToTronJSON(fv, fd, visible)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", k, err)
+		}
+	}
+	return obj, nil
+}
+
+func fieldToTronJSON(v interface{}, fd protoreflect.FieldDescriptor, visible bool) (interface{}, error) {
+	switch fd.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return toTronJSON(v, fd.Message(), visible)
+	case protoreflect.BytesKind:
+		s, ok := v.(string)
+		if !ok {
+			return v, nil
+		}
+		bs, err := base64.StdEncoding.DecodeString(s)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case visible && visibleNameFields[fd.FullName()]:
+			return string(bs), nil
+		case visible && visibleAddressFields[fd.FullName()] && address.Address(bs).IsValid():
+			return common.EncodeCheck(bs), nil
+		default:
+			return hex.EncodeToString(bs), nil
+		}
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind,
+		protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		// TRON HTTP API expects numbers rather than the quoted 64-bit integers of protojson
+		if s, ok := v.(string); ok {
+			return json.Number(s), nil
+		}
+		return v, nil
+	default:
+		return v, nil
+	}
+}
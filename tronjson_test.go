@@ -0,0 +1,129 @@
+package go_tronsdk
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+func TestVisibleFields(t *testing.T) {
+	for _, fields := range []map[protoreflect.FullName]bool{visibleAddressFields, visibleNameFields} {
+		for name := range fields {
+			d, err := protoregistry.GlobalFiles.FindDescriptorByName(name)
+			if err != nil {
+				t.Fatalf("%s: %v", name, err)
+			}
+			if fd, ok := d.(protoreflect.FieldDescriptor); !ok || fd.Kind() != protoreflect.BytesKind {
+				t.Fatalf("%s is not a bytes field", name)
+			}
+		}
+	}
+}
+
+func TestTronJSON_VisibleRoundTrip(t *testing.T) {
+	tests := []struct {
+		path    string
+		payload string
+		msg     proto.Message
+		check   func(m proto.Message) bool
+	}{
+		{
+			"/wallet/createtransaction",
+			`{"visible":true,"raw_data":{"contract":[{"parameter":{"value":{"amount":1000,"owner_address":"TJmmqjb1DK9TTZbQXzRQ2AuA94z4gKAPFh",` +
+				`"to_address":"TNPeeaaFB7K9cmo4uQpcU32zGK8G1NYqeL"},"type_url":"type.googleapis.com/protocol.TransferContract"},` +
+				`"type":"TransferContract"}],"ref_block_bytes":"5e4b","ref_block_hash":"47c9dc89341b300d",` +
+				`"expiration":1591089627000,"timestamp":1591089567635}}`,
+			new(core.Transaction),
+			func(m proto.Message) bool {
+				var transfer core.TransferContract
+				raw := m.(*core.Transaction).RawData
+				return raw.Contract[0].Parameter.UnmarshalTo(&transfer) == nil &&
+					transfer.OwnerAddress[0] == address.TronBytePrefix && len(transfer.ToAddress) == address.AddressLength &&
+					bytes.Equal(raw.RefBlockBytes, []byte{0x5e, 0x4b})
+			},
+		},
+		{
+			"/wallet/getaccount",
+			`{"account_name":"testacc2","address":"TJmmqjb1DK9TTZbQXzRQ2AuA94z4gKAPFh","balance":1000000,` +
+				`"create_time":1591089567000,"owner_permission":{"permission_name":"owner","threshold":1,` +
+				`"keys":[{"address":"TJmmqjb1DK9TTZbQXzRQ2AuA94z4gKAPFh","weight":1}]},` +
+				`"active_permission":[{"type":"Active","id":2,"permission_name":"active","threshold":1,` +
+				`"operations":"7fff1fc0033e0000000000000000000000000000000000000000000000000000",` +
+				`"keys":[{"address":"TNPeeaaFB7K9cmo4uQpcU32zGK8G1NYqeL","weight":1}]}],` +
+				`"assetV2":[{"key":"1002000","value":10}]}`,
+			new(core.Account),
+			func(m proto.Message) bool {
+				acc := m.(*core.Account)
+				return string(acc.AccountName) == "testacc2" && len(acc.ActivePermission[0].Operations) == 32 &&
+					address.Address(acc.ActivePermission[0].Keys[0].Address).String() == "TNPeeaaFB7K9cmo4uQpcU32zGK8G1NYqeL" &&
+					acc.AssetV2["1002000"] == 10
+			},
+		},
+		{
+			"/wallet/getassetissuebyid",
+			`{"owner_address":"TJmmqjb1DK9TTZbQXzRQ2AuA94z4gKAPFh","name":"BitTorrent","abbr":"BTT",` +
+				`"total_supply":990000000000000000,"trx_num":1,"precision":6,"num":1,"start_time":1548000000000,` +
+				`"end_time":1548000001000,"description":"Official Token of BitTorrent Protocol",` +
+				`"url":"www.bittorrent.com","id":"1002000"}`,
+			new(core.AssetIssueContract),
+			func(m proto.Message) bool {
+				asset := m.(*core.AssetIssueContract)
+				return string(asset.Name) == "BitTorrent" && string(asset.Url) == "www.bittorrent.com" && asset.Id == "1002000"
+			},
+		},
+		{
+			"/wallet/gettransactioninfobyid",
+			`{"id":"c558bd35e2ff1bd5b6ee43d5ea4bd2e1acb20d1bd0bcc1c0e8b3c4d2e1f0a9b8","fee":345000,"blockNumber":52348412,` +
+				`"blockTimeStamp":1687330326000,"contractResult":["0000000000000000000000000000000000000000000000000000000000000001"],` +
+				`"contract_address":"TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t","receipt":{"energy_usage_total":31895,` +
+				`"net_usage":345,"result":"SUCCESS","energy_penalty_total":17245},"log":[{"address":"a614f803b6fd780986a42c78ec9c7f77e6ded13c",` +
+				`"topics":["ddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef",` +
+				`"000000000000000000000000608f8da72479edc7dd921e4c30bb7e7cddbe722e",` +
+				`"0000000000000000000000008840e6c55b9ada326d211d818c34a994aeced808"],` +
+				`"data":"00000000000000000000000000000000000000000000000000000000000f4240"}]}`,
+			new(core.TransactionInfo),
+			func(m proto.Message) bool {
+				info := m.(*core.TransactionInfo)
+				return address.Address(info.ContractAddress).String() == "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t" &&
+					len(info.Log[0].Address) == 20 && info.Receipt.Result == core.Transaction_Result_SUCCESS
+			},
+		},
+		{
+			"/wallet/broadcasttransaction",
+			`{"code":"SIGERROR","message":"validate signature error"}`,
+			new(api.Return),
+			func(m proto.Message) bool {
+				return string(m.(*api.Return).Message) == "validate signature error"
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			if err := unmarshalTronJSON([]byte(tt.payload), tt.msg, true); err != nil {
+				t.Fatal(err)
+			}
+			if !tt.check(tt.msg) {
+				t.Fatalf("unexpected %v", tt.msg)
+			}
+			data, err := marshalTronJSON(tt.msg, true)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var expected, got map[string]interface{}
+			_ = json.Unmarshal([]byte(tt.payload), &expected)
+			_ = json.Unmarshal(data, &got)
+			expected["visible"] = true
+			if !reflect.DeepEqual(expected, got) {
+				t.Fatalf("round trip mismatch:\n%s\n%s", tt.payload, data)
+			}
+		})
+	}
+}
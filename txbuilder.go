@@ -0,0 +1,138 @@
+package go_tronsdk
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+const DefaultTxExpiration = time.Minute
+
+var ErrAccountNotActivated = errors.New("account not activated")
+
+// TxOptions of the transactions built by TronClient, nil for the defaults
+type TxOptions struct {
+	// FeeLimit in sun, the max TRX could be burnt for energy, only for contract calls. It is
+	// estimated if it is AutoFeeLimit.
+	FeeLimit int64
+	// FeeLimitMargin is the safety margin of AutoFeeLimit, the one of the client if not positive
+	FeeLimitMargin float64
+	// Memo is put into raw_data.data of the transaction
+	Memo []byte
+	// Expiration after the reference block, DefaultTxExpiration if not positive
+	Expiration time.Duration
+	// ActivateRecipient allows sending TRX or tokens to an address which is not activated yet,
+	// which costs the sender an extra activation fee. Otherwise ErrAccountNotActivated is returned.
+	ActivateRecipient bool
+	// PermissionID is the Permission_id of the contract, the permission of the owner which
+	// signs the transaction: 0 for the owner permission (default), 2 and above for the active
+	// permissions
+	PermissionID int32
+}
+
+func (o *TxOptions) expiration() time.Duration {
+	if o == nil || o.Expiration <= 0 {
+		return DefaultTxExpiration
+	}
+	return o.Expiration
+}
+
+// newTx builds an unsigned transaction of the builtin contract, referring to the latest block
+func (c *TronClient) newTx(ctx context.Context, typ core.Transaction_Contract_ContractType, contract proto.Message,
+	opts *TxOptions) (*core.Transaction, error) {
+	param, err := anypb.New(contract)
+	if err != nil {
+		return nil, err
+	}
+	blk, err := c.GetNowBlock(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get reference block failed: %w", err)
+	}
+	if blk == nil || blk.BlockHeader == nil || blk.BlockHeader.RawData == nil || len(blk.Blockid) != 32 {
+		return nil, errors.New("invalid reference block")
+	}
+	num := make([]byte, 8)
+	binary.BigEndian.PutUint64(num, uint64(blk.BlockHeader.RawData.Number))
+	raw := &core.TransactionRaw{
+		RefBlockBytes: num[6:8],
+		RefBlockHash:  blk.Blockid[8:16],
+		Expiration:    blk.BlockHeader.RawData.Timestamp + opts.expiration().Milliseconds(),
+		Timestamp:     time.Now().UnixMilli(),
+		Contract:      []*core.Transaction_Contract{{Type: typ, Parameter: param}},
+	}
+	if opts != nil {
+		// the fee limit is only for contract calls, AutoFeeLimit is estimated by the caller
+		if opts.FeeLimit > 0 && (typ == core.Transaction_Contract_TriggerSmartContract ||
+			typ == core.Transaction_Contract_CreateSmartContract) {
+			raw.FeeLimit = opts.FeeLimit
+		}
+		raw.Data = opts.Memo
+		raw.Contract[0].PermissionId = opts.PermissionID
+	}
+	return &core.Transaction{RawData: raw}, nil
+}
+
+// checkActivated returns ErrAccountNotActivated if addr does not exist on chain, unless the
+// activation is allowed by opts
+func (c *TronClient) checkActivated(ctx context.Context, addr address.Address, opts *TxOptions) error {
+	if opts != nil && opts.ActivateRecipient {
+		return nil
+	}
+	acc, err := c.GetAccount(ctx, addr)
+	if err != nil {
+		return fmt.Errorf("get account %s failed: %w", addr, err)
+	}
+	if acc == nil || len(acc.Address) == 0 {
+		return fmt.Errorf("%w: %s", ErrAccountNotActivated, addr)
+	}
+	return nil
+}
+
+// sendContract builds the transaction of the builtin contract, then signs and broadcasts it.
+// The owner_address of the contract is the signer if not set.
+func (c *TronClient) sendContract(ctx context.Context, signer Signer, typ core.Transaction_Contract_ContractType,
+	contract proto.Message, opts *TxOptions) (*api.TransactionExtention, error) {
+	if signer == nil {
+		return nil, errors.New("nil signer")
+	}
+	if contract != nil {
+		m := contract.ProtoReflect()
+		fd := m.Descriptor().Fields().ByName("owner_address")
+		if fd != nil && fd.Kind() == protoreflect.BytesKind && len(m.Get(fd).Bytes()) == 0 {
+			m.Set(fd, protoreflect.ValueOfBytes(signer.Address()))
+		}
+	}
+	tx, err := c.newTx(ctx, typ, contract, opts)
+	if err != nil {
+		return nil, err
+	}
+	return c.signAndBroadcast(ctx, signer, tx)
+}
+
+// signAndBroadcast signs tx by signer and broadcasts it. The signed transaction is returned
+// with its txid even if the broadcast failed.
+func (c *TronClient) signAndBroadcast(ctx context.Context, signer Signer, tx *core.Transaction) (*api.TransactionExtention, error) {
+	txId, err := HashMessage(tx.RawData)
+	if err != nil {
+		return nil, err
+	}
+	sig, err := signTx(ctx, signer, txId, tx.RawData)
+	if err != nil {
+		return nil, fmt.Errorf("sign failed: %w", err)
+	}
+	tx.Signature = append(tx.Signature, sig)
+	txx := &api.TransactionExtention{Transaction: tx, Txid: txId}
+	if err = c.BroadcastTransaction(ctx, tx); err != nil {
+		return txx, err
+	}
+	return txx, nil
+}
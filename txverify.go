@@ -0,0 +1,202 @@
+package go_tronsdk
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const (
+	OwnerPermissionID   = 0
+	WitnessPermissionID = 1
+	// ActivePermissionStartID is the id of the first active permission, active permissions are
+	// numbered from it
+	ActivePermissionStartID = 2
+)
+
+var (
+	ErrPermissionNotFound  = errors.New("permission not found")
+	ErrPermissionDenied    = errors.New("permission denied")
+	ErrSignerNotPermitted  = errors.New("signer not in permission")
+	ErrDuplicatedSignature = errors.New("signed twice by one signer")
+)
+
+// TxID returns the sha256 of the raw data, which is signed by the signatures
+func (t *Tx) TxID() ([]byte, error) {
+	if t == nil || t.RawData == nil {
+		return nil, ErrInvalidTx
+	}
+	return HashMessage(t.RawData)
+}
+
+// Signers recovers the address of each signature over the txid, in the order of signatures
+func (t *Tx) Signers() ([]address.Address, error) {
+	txId, err := t.TxID()
+	if err != nil {
+		return nil, err
+	}
+	signers := make([]address.Address, 0, len(t.Signature))
+	for i, sig := range t.Signature {
+		addr, err := recoverHashSigner(txId, sig)
+		if err != nil {
+			return nil, fmt.Errorf("signature %d: %w", i, err)
+		}
+		signers = append(signers, addr)
+	}
+	return signers, nil
+}
+
+// Contract returns the only contract of the transaction
+func (t *Tx) Contract() (*core.Transaction_Contract, error) {
+	if t == nil || t.RawData == nil || len(t.RawData.Contract) != 1 || t.RawData.Contract[0] == nil {
+		return nil, ErrInvalidTx
+	}
+	return t.RawData.Contract[0], nil
+}
+
+// OwnerAddress returns the owner_address of the contract, whose permission is required
+func (t *Tx) OwnerAddress() (address.Address, error) {
+	contract, err := t.Contract()
+	if err != nil {
+		return nil, err
+	}
+	if contract.Parameter == nil {
+		return nil, ErrInvalidTx
+	}
+	msg, err := contract.Parameter.UnmarshalNew()
+	if err != nil {
+		return nil, err
+	}
+	field := msg.ProtoReflect().Descriptor().Fields().ByName("owner_address")
+	if field == nil || field.Kind() != protoreflect.BytesKind {
+		return nil, fmt.Errorf("no owner address in %s", contract.Type)
+	}
+	return msg.ProtoReflect().Get(field).Bytes(), nil
+}
+
+// PermissionOf returns the permission of account by id, the same as the fullnode. The default
+// owner permission (the account itself with threshold 1) is returned if the owner permission
+// is not set.
+func PermissionOf(account *core.Account, id int32) (*core.Permission, error) {
+	if account == nil {
+		return nil, errors.New("nil account")
+	}
+	switch {
+	case id == OwnerPermissionID:
+		if account.OwnerPermission != nil {
+			return account.OwnerPermission, nil
+		}
+		return &core.Permission{
+			Type:           core.Permission_Owner,
+			Id:             OwnerPermissionID,
+			PermissionName: "owner",
+			Threshold:      1,
+			Keys:           []*core.Key{{Address: account.Address, Weight: 1}},
+		}, nil
+	case id == WitnessPermissionID:
+		if account.WitnessPermission != nil {
+			return account.WitnessPermission, nil
+		}
+	default:
+		for _, p := range account.ActivePermission {
+			if p != nil && p.Id == id {
+				return p, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("%w: %d", ErrPermissionNotFound, id)
+}
+
+// PermissionAllows checks whether the operations of permission include the contract type,
+// which is always true for the owner permission
+func PermissionAllows(permission *core.Permission, typ core.Transaction_Contract_ContractType) bool {
+	if permission == nil {
+		return false
+	}
+	if permission.Type != core.Permission_Active {
+		return permission.Type == core.Permission_Owner
+	}
+	i := int(typ)
+	return i/8 < len(permission.Operations) && permission.Operations[i/8]&(1<<(i%8)) != 0
+}
+
+// SignWeight is the weight of signatures of a transaction in the permission
+type SignWeight struct {
+	Permission *core.Permission
+	Approved   []address.Address // signers in the order of signatures
+	Weight     int64
+}
+
+// Enough reports whether the weight reaches the threshold of the permission
+func (w *SignWeight) Enough() bool {
+	return w.Permission != nil && w.Weight >= w.Permission.Threshold
+}
+
+func (w *SignWeight) String() string {
+	if w == nil || w.Permission == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("{permission:%d %d/%d approved:%s}", w.Permission.Id, w.Weight, w.Permission.Threshold, w.Approved)
+}
+
+// VerifyAgainstPermission checks the signers of the transaction against the permission chosen
+// by the Permission_id of the contract in account, which should be the owner of the contract.
+// It is the local equivalent of GetTransactionSignWeight, and fails if any signature is invalid,
+// duplicated or not in the permission. Check SignWeight.Enough for the threshold.
+func (t *Tx) VerifyAgainstPermission(account *core.Account) (*SignWeight, error) {
+	contract, err := t.Contract()
+	if err != nil {
+		return nil, err
+	}
+	owner, err := t.OwnerAddress()
+	if err != nil {
+		return nil, err
+	}
+	if account == nil || !bytes.Equal(owner, account.Address) {
+		return nil, fmt.Errorf("account is not the owner %s", owner)
+	}
+	permission, err := PermissionOf(account, contract.PermissionId)
+	if err != nil {
+		return nil, err
+	}
+	if contract.PermissionId != OwnerPermissionID {
+		if permission.Type != core.Permission_Active {
+			return nil, fmt.Errorf("%w: permission %d is not active", ErrPermissionDenied, contract.PermissionId)
+		}
+		if !PermissionAllows(permission, contract.Type) {
+			return nil, fmt.Errorf("%w: %s not allowed by permission %d", ErrPermissionDenied, contract.Type, contract.PermissionId)
+		}
+	}
+	if len(t.Signature) > len(permission.Keys) {
+		return nil, fmt.Errorf("%d signatures more than %d keys of permission", len(t.Signature), len(permission.Keys))
+	}
+	signers, err := t.Signers()
+	if err != nil {
+		return nil, err
+	}
+	weights := make(map[string]int64, len(permission.Keys))
+	for _, k := range permission.Keys {
+		if k != nil {
+			weights[string(k.Address)] = k.Weight
+		}
+	}
+	w := &SignWeight{Permission: permission, Approved: make([]address.Address, 0, len(signers))}
+	signed := make(map[string]bool, len(signers))
+	for _, s := range signers {
+		weight, ok := weights[string(s)]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrSignerNotPermitted, s)
+		}
+		if signed[string(s)] {
+			return nil, fmt.Errorf("%w: %s", ErrDuplicatedSignature, s)
+		}
+		signed[string(s)] = true
+		w.Approved = append(w.Approved, s)
+		w.Weight += weight
+	}
+	return w, nil
+}
@@ -0,0 +1,111 @@
+package go_tronsdk
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"testing"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+func testSigners(t *testing.T, n int) []*PrivateKeySigner {
+	signers := make([]*PrivateKeySigner, n)
+	for i := range signers {
+		priv := sha256.Sum256([]byte{byte(i)})
+		s, err := NewPrivateKeySigner(priv[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		signers[i] = s
+	}
+	return signers
+}
+
+func testTransferTx(t *testing.T, owner []byte, permissionId int32) *Tx {
+	param, err := anypb.New(&core.TransferContract{OwnerAddress: owner, ToAddress: bytes.Repeat([]byte{0x41}, 21), Amount: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &Tx{RawData: &core.TransactionRaw{
+		Contract: []*core.Transaction_Contract{{
+			Type:         core.Transaction_Contract_TransferContract,
+			Parameter:    param,
+			PermissionId: permissionId,
+		}},
+		Expiration: 1_700_000_060_000,
+		Timestamp:  1_700_000_000_000,
+	}}
+}
+
+func testSignTx(t *testing.T, tx *Tx, signers ...Signer) {
+	txId, err := tx.TxID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range signers {
+		sig, err := s.SignTxID(context.Background(), txId)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tx.Signature = append(tx.Signature, sig)
+	}
+}
+
+func TestTx_VerifyAgainstPermission(t *testing.T) {
+	signers := testSigners(t, 4)
+	owner := signers[0].Address()
+	active := &core.Permission{Type: core.Permission_Active, Id: 2, Threshold: 3,
+		Operations: make([]byte, 32),
+		Keys: []*core.Key{
+			{Address: signers[1].Address(), Weight: 1},
+			{Address: signers[2].Address(), Weight: 2},
+			{Address: signers[3].Address(), Weight: 1},
+		}}
+	active.Operations[core.Transaction_Contract_TransferContract/8] |= 1 << (core.Transaction_Contract_TransferContract % 8)
+	account := &core.Account{Address: owner, ActivePermission: []*core.Permission{active}}
+
+	// default owner permission
+	tx := testTransferTx(t, owner, 0)
+	testSignTx(t, tx, signers[0])
+	if addrs, err := tx.Signers(); err != nil || len(addrs) != 1 || !bytes.Equal(addrs[0], owner) {
+		t.Fatalf("unexpected signers %v %v", addrs, err)
+	}
+	if w, err := tx.VerifyAgainstPermission(account); err != nil || !w.Enough() {
+		t.Fatalf("owner signature should be enough, got %v %v", w, err)
+	}
+
+	tx = testTransferTx(t, owner, 2)
+	testSignTx(t, tx, signers[1], signers[3])
+	w, err := tx.VerifyAgainstPermission(account)
+	if err != nil || w.Enough() || w.Weight != 2 || len(w.Approved) != 2 {
+		t.Fatalf("expecting weight 2 not enough, got %v %v", w, err)
+	}
+	testSignTx(t, tx, signers[2])
+	if w, err = tx.VerifyAgainstPermission(account); err != nil || !w.Enough() || w.Weight != 4 {
+		t.Fatalf("expecting weight 4 enough, got %v %v", w, err)
+	}
+
+	tx = testTransferTx(t, owner, 2)
+	testSignTx(t, tx, signers[1], signers[1])
+	if _, err = tx.VerifyAgainstPermission(account); !errors.Is(err, ErrDuplicatedSignature) {
+		t.Fatalf("expecting duplicated signature, got %v", err)
+	}
+	tx = testTransferTx(t, owner, 2)
+	testSignTx(t, tx, signers[0])
+	if _, err = tx.VerifyAgainstPermission(account); !errors.Is(err, ErrSignerNotPermitted) {
+		t.Fatalf("expecting signer not permitted, got %v", err)
+	}
+	if _, err = testTransferTx(t, owner, 3).VerifyAgainstPermission(account); !errors.Is(err, ErrPermissionNotFound) {
+		t.Fatalf("expecting permission not found, got %v", err)
+	}
+	active.Operations = make([]byte, 32)
+	if _, err = testTransferTx(t, owner, 2).VerifyAgainstPermission(account); !errors.Is(err, ErrPermissionDenied) {
+		t.Fatalf("expecting permission denied, got %v", err)
+	}
+	if _, err = testTransferTx(t, signers[1].Address(), 0).VerifyAgainstPermission(account); err == nil {
+		t.Fatal("expecting owner mismatch")
+	}
+}
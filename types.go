@@ -10,12 +10,27 @@ import (
 )
 
 var (
-	ErrInvalidTx          = errors.New("invalid transaction")
-	ErrNotTriggerContract = errors.New("not trigger contract")
-	ErrTxNotFound         = errors.New("transation not found")
-	ErrTxResultNotFound   = errors.New("transaction result not found")
+	ErrInvalidTx            = errors.New("invalid transaction")
+	ErrNotTriggerContract   = errors.New("not trigger contract")
+	ErrTxNotFound           = errors.New("transation not found")
+	ErrTxResultNotFound     = errors.New("transaction result not found")
+	ErrTransportUnavailable = errors.New("transport unavailable")
 )
 
+// TransportUnavailableError is returned when the transport required by a method is not configured.
+// errors.Is(err, ErrTransportUnavailable) reports true for it.
+type TransportUnavailableError struct {
+	Transport Transport
+}
+
+func (e TransportUnavailableError) Error() string {
+	return fmt.Sprintf("%s transport unavailable", e.Transport)
+}
+
+func (e TransportUnavailableError) Is(target error) bool {
+	return target == ErrTransportUnavailable
+}
+
 type Receipt struct {
 	BlockNum           int64
 	Timestamp          int64
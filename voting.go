@@ -0,0 +1,162 @@
+package go_tronsdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+)
+
+// MaxVoteWitnesses is the max number of witnesses voted by a VoteWitnessContract
+const MaxVoteWitnesses = 30
+
+// Vote is the votes cast for a witness (SR)
+type Vote struct {
+	Witness address.Address
+	Count   int64
+}
+
+func (v *Vote) String() string {
+	return fmt.Sprintf("{%s:%d}", v.Witness, v.Count)
+}
+
+// VotingInfo is the votes of an account, 1 Tron Power is 1 TRX staked and could cast 1 vote
+type VotingInfo struct {
+	Votes         []*Vote
+	TronPower     int64
+	TronPowerUsed int64
+}
+
+// Available returns the Tron Power not used by votes
+func (v *VotingInfo) Available() int64 {
+	return v.TronPower - v.TronPowerUsed
+}
+
+// GetVotingInfo gets the current votes and Tron Power of the account
+func (c *TronClient) GetVotingInfo(ctx context.Context, addr address.Address) (*VotingInfo, error) {
+	acc, err := c.GetAccount(ctx, addr)
+	if err != nil {
+		return nil, err
+	}
+	res, err := c.GetAccountResource(ctx, addr)
+	if err != nil {
+		return nil, err
+	}
+	info := &VotingInfo{TronPower: res.TronPowerLimit, TronPowerUsed: res.TronPowerUsed}
+	for _, v := range acc.GetVotes() {
+		if v != nil {
+			info.Votes = append(info.Votes, &Vote{Witness: v.VoteAddress, Count: v.VoteCount})
+		}
+	}
+	return info, nil
+}
+
+// GetRewardInfo gets the voting rewards of addr not withdrawn yet, in sun
+func (c *TronClient) GetRewardInfo(ctx context.Context, addr address.Address, cons ...Consistency) (int64, error) {
+	return _consistentRun(ctx, c, "GetRewardInfo", cons, func(ctx context.Context, b ReadBackend) (int64, error) {
+		return b.GetRewardInfo(ctx, addr)
+	})
+}
+
+// GetBrokerageInfo gets the percent of the rewards kept by the witness, the rest goes to voters
+func (c *TronClient) GetBrokerageInfo(ctx context.Context, witness address.Address, cons ...Consistency) (int64, error) {
+	return _consistentRun(ctx, c, "GetBrokerageInfo", cons, func(ctx context.Context, b ReadBackend) (int64, error) {
+		return b.GetBrokerageInfo(ctx, witness)
+	})
+}
+
+// VoteWitness casts votes of the signer, which replace all its previous votes. The voting
+// rewards not withdrawn are settled by the vote.
+func (c *TronClient) VoteWitness(ctx context.Context, signer Signer, votes []*Vote, opts *TxOptions) (*api.TransactionExtention, error) {
+	if len(votes) == 0 || len(votes) > MaxVoteWitnesses {
+		return nil, fmt.Errorf("number of witnesses should be in [1, %d]", MaxVoteWitnesses)
+	}
+	vc := new(core.VoteWitnessContract)
+	seen := make(map[string]bool, len(votes))
+	for _, v := range votes {
+		if v == nil || !v.Witness.IsValid() {
+			return nil, errors.New("invalid witness address")
+		}
+		if v.Count <= 0 {
+			return nil, fmt.Errorf("votes for %s should be positive", v.Witness)
+		}
+		if seen[v.Witness.String()] {
+			return nil, fmt.Errorf("duplicated witness %s", v.Witness)
+		}
+		seen[v.Witness.String()] = true
+		vc.Votes = append(vc.Votes, &core.VoteWitnessContract_Vote{VoteAddress: v.Witness, VoteCount: v.Count})
+	}
+	return c.sendContract(ctx, signer, core.Transaction_Contract_VoteWitnessContract, vc, opts)
+}
+
+// WithdrawBalance withdraws the voting rewards (and the block rewards of a witness) to the
+// balance of the signer, at most once every 24 hours
+func (c *TronClient) WithdrawBalance(ctx context.Context, signer Signer, opts *TxOptions) (*api.TransactionExtention, error) {
+	return c.sendContract(ctx, signer, core.Transaction_Contract_WithdrawBalanceContract,
+		&core.WithdrawBalanceContract{}, opts)
+}
+
+// DistributeVotes splits power among the witnesses (base58 addresses) in proportion to their
+// weights, the remainders go to the largest fractions. Witnesses getting no votes are omitted,
+// and the votes are in the order of addresses.
+func DistributeVotes(power int64, weights map[string]int64) ([]*Vote, error) {
+	if power <= 0 {
+		return nil, errors.New("power should be positive")
+	}
+	if len(weights) == 0 || len(weights) > MaxVoteWitnesses {
+		return nil, fmt.Errorf("number of witnesses should be in [1, %d]", MaxVoteWitnesses)
+	}
+	type share struct {
+		vote      *Vote
+		remainder *big.Int
+	}
+	// the weights are summed in big.Int as the quotients, which could overflow int64
+	total := new(big.Int)
+	shares := make([]*share, 0, len(weights))
+	for k, w := range weights {
+		addr, err := address.Base58ToAddress(k)
+		if err != nil {
+			return nil, fmt.Errorf("invalid witness %s: %w", k, err)
+		}
+		if w < 0 {
+			return nil, fmt.Errorf("negative weight of %s", k)
+		}
+		total.Add(total, big.NewInt(w))
+		shares = append(shares, &share{vote: &Vote{Witness: addr, Count: w}})
+	}
+	if total.Sign() <= 0 {
+		return nil, errors.New("no weight")
+	}
+	sort.Slice(shares, func(i, j int) bool {
+		return shares[i].vote.Witness.String() < shares[j].vote.Witness.String()
+	})
+	left := power
+	q := new(big.Int)
+	for _, s := range shares {
+		s.remainder = new(big.Int)
+		q.QuoRem(new(big.Int).Mul(big.NewInt(power), big.NewInt(s.vote.Count)), total, s.remainder)
+		s.vote.Count = q.Int64()
+		left -= s.vote.Count
+	}
+	byRemainder := make([]*share, len(shares))
+	copy(byRemainder, shares)
+	sort.SliceStable(byRemainder, func(i, j int) bool {
+		return byRemainder[i].remainder.Cmp(byRemainder[j].remainder) > 0
+	})
+	for i := 0; left > 0; i++ {
+		byRemainder[i].vote.Count++
+		left--
+	}
+	votes := make([]*Vote, 0, len(shares))
+	for _, s := range shares {
+		if s.vote.Count > 0 {
+			votes = append(votes, s.vote)
+		}
+	}
+	return votes, nil
+}
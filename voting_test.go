@@ -0,0 +1,127 @@
+package go_tronsdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"google.golang.org/grpc"
+)
+
+type votingWallet struct {
+	*fakeWallet
+}
+
+func (w *votingWallet) GetAccount(_ context.Context, in *core.Account, _ ...grpc.CallOption) (*core.Account, error) {
+	witness, _ := hex.DecodeString(testToHex)
+	return &core.Account{Address: in.Address, Votes: []*core.Vote{{VoteAddress: witness, VoteCount: 60}}}, nil
+}
+
+func (w *votingWallet) GetAccountResource(_ context.Context, _ *core.Account, _ ...grpc.CallOption) (*api.AccountResourceMessage, error) {
+	return &api.AccountResourceMessage{TronPowerLimit: 100, TronPowerUsed: 60}, nil
+}
+
+func (w *votingWallet) GetRewardInfo(_ context.Context, _ *api.BytesMessage, _ ...grpc.CallOption) (*api.NumberMessage, error) {
+	return &api.NumberMessage{Num: 12345}, nil
+}
+
+func TestDistributeVotes(t *testing.T) {
+	a := address.HexToAddress("41" + "01" + hex.EncodeToString(make([]byte, 19)))
+	b := address.HexToAddress("41" + "02" + hex.EncodeToString(make([]byte, 19)))
+	c := address.HexToAddress("41" + "03" + hex.EncodeToString(make([]byte, 19)))
+	votes, err := DistributeVotes(100, map[string]int64{a.String(): 1, b.String(): 1, c.String(): 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var sum int64
+	for _, v := range votes {
+		sum += v.Count
+		if v.Count != 33 && v.Count != 34 {
+			t.Fatalf("unexpected votes %v", votes)
+		}
+	}
+	if sum != 100 || len(votes) != 3 {
+		t.Fatalf("unexpected votes %v", votes)
+	}
+	if votes, err = DistributeVotes(10, map[string]int64{a.String(): 3, b.String(): 0, c.String(): 7}); err != nil ||
+		len(votes) != 2 || votes[0].Count+votes[1].Count != 10 {
+		t.Fatalf("unexpected votes %v %v", votes, err)
+	}
+	// the sum of the weights overflows int64
+	votes, err = DistributeVotes(101, map[string]int64{a.String(): math.MaxInt64, b.String(): math.MaxInt64, c.String(): 1})
+	if err != nil || len(votes) != 2 || votes[0].Count+votes[1].Count != 101 || votes[0].Count < 50 || votes[1].Count < 50 {
+		t.Fatalf("unexpected votes %v %v", votes, err)
+	}
+	if _, err = DistributeVotes(10, map[string]int64{"T-invalid": 1}); err == nil {
+		t.Fatal("expecting invalid witness")
+	}
+}
+
+func TestTronClient_VoteWitness(t *testing.T) {
+	w := &votingWallet{&fakeWallet{name: "fake", height: 100}}
+	client, err := NewClient(context.Background(), WithBackend("fake", NewGrpcBackend(w)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = client.Close()
+	}()
+	priv, _ := hex.DecodeString("4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318")
+	signer, _ := NewPrivateKeySigner(priv)
+	witness, _ := hex.DecodeString(testToHex)
+
+	info, err := client.GetVotingInfo(context.Background(), signer.Address())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Available() != 40 || len(info.Votes) != 1 || !bytes.Equal(info.Votes[0].Witness, witness) {
+		t.Fatalf("unexpected voting info %+v", info)
+	}
+	if reward, err := client.GetRewardInfo(context.Background(), signer.Address()); err != nil || reward != 12345 {
+		t.Fatalf("expecting reward 12345, got %d %v", reward, err)
+	}
+
+	txx, err := client.VoteWitness(context.Background(), signer, []*Vote{{Witness: witness, Count: 100}}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	vc := new(core.VoteWitnessContract)
+	if err = txx.Transaction.RawData.Contract[0].Parameter.UnmarshalTo(vc); err != nil {
+		t.Fatal(err)
+	}
+	if len(vc.Votes) != 1 || vc.Votes[0].VoteCount != 100 || !bytes.Equal(vc.Votes[0].VoteAddress, witness) {
+		t.Fatalf("unexpected contract %v", vc)
+	}
+	if _, err = client.VoteWitness(context.Background(), signer, []*Vote{{Witness: witness, Count: 1}, {Witness: witness, Count: 2}}, nil); err == nil {
+		t.Fatal("expecting duplicated witness")
+	}
+	if txx, err = client.WithdrawBalance(context.Background(), signer, nil); err != nil ||
+		txx.Transaction.RawData.Contract[0].Type != core.Transaction_Contract_WithdrawBalanceContract {
+		t.Fatalf("withdraw balance failed: %v", err)
+	}
+}
+
+func TestHttpClient_GetBrokerageInfo(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		if r.URL.Path != "/wallet/getBrokerage" || req["address"] != testToHex {
+			t.Errorf("unexpected request %s %v", r.URL.Path, req)
+		}
+		_, _ = w.Write([]byte(`{"brokerage":20}`))
+	}))
+	defer server.Close()
+	witness, _ := hex.DecodeString(testToHex)
+	brokerage, err := NewHttpClient(server.URL, 1).GetBrokerageInfo(context.Background(), witness)
+	if err != nil || brokerage != 20 {
+		t.Fatalf("expecting 20, got %d %v", brokerage, err)
+	}
+}